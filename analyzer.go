@@ -0,0 +1,73 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+// Analyzer turns a text into index terms. The text is split by the
+// tokenizer and the resulting tokens go through each filter in order.
+type Analyzer struct {
+    Tokenizer func(text string) []Token // Splits text, Tokenize if nil
+    Filters   []TokenFilter             // Filters applied after tokenization
+}
+
+// Create the standard portuguese analyzer: tokens are lowercased,
+// contractions are expanded, stop words are removed and the remaining
+// tokens are stemmed. If stemmer is nil, tokens are not stemmed.
+func NewAnalyzer(stemmer Stemmer) *Analyzer {
+    a := new(Analyzer)
+    a.Filters = []TokenFilter{
+        LowercaseFilter{},
+        NewContractionFilter(nil),
+        NewStopwordFilter(nil),
+    }
+    if stemmer != nil {
+        a.Filters = append(a.Filters, StemFilter{stemmer})
+    }
+    return a
+}
+
+// Analyze tokenizes the text and applies every filter.
+func (a *Analyzer) Analyze(text string) []Token {
+    tokenize := a.Tokenizer
+    if tokenize == nil {
+        tokenize = Tokenize
+    }
+    tokens := tokenize(text)
+    for _, f := range a.Filters {
+        tokens = f.Filter(tokens)
+    }
+    return tokens
+}
+
+// Terms returns the text of the tokens produced by Analyze.
+func (a *Analyzer) Terms(text string) []string {
+    tokens := a.Analyze(text)
+    res := make([]string, len(tokens))
+    for i, t := range tokens {
+        res[i] = t.Text
+    }
+    return res
+}
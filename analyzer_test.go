@@ -0,0 +1,54 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "testing"
+)
+
+// TestAnalyzer checks if the standard analyzer expands contractions,
+// removes stop words and stems the remaining words.
+func TestAnalyzer(t *testing.T) {
+    var cases = []struct {
+        stemmer Stemmer
+        text    string
+        terms   string
+    }{
+        {NewPorterStemmer(), "Os alunos ajudaram daquela vez.", "alun ajud vez"},
+        {NewPorterStemmer(), "Não foi isso.", ""},
+        {nil, "As Casas do Povo", "casas povo"},
+    }
+
+    for _, c := range cases {
+        terms := strings.Join(NewAnalyzer(c.stemmer).Terms(c.text), " ")
+        if terms != c.terms {
+            t.Errorf("Invalid terms. text= %s expected= %s actual= %s\n",
+                c.text, c.terms, terms)
+        }
+    }
+}
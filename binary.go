@@ -0,0 +1,240 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bytes"
+    "encoding/binary"
+    "errors"
+    "hash/crc32"
+    "sort"
+)
+
+// Binary encoding used to store suffix trees and compiled stemmers. Every
+// encoded value starts with a header made of the magic bytes, the format
+// version and the kind of the encoded value, and ends with a CRC-32
+// checksum of everything that precedes it.
+const (
+    binaryMagic   = "PTST"
+    binaryVersion = 1
+
+    kindSuffixTree    = 1
+    kindPorterStemmer = 2
+)
+
+var (
+    // ErrInvalidData is returned when decoding truncated or malformed
+    // binary data.
+    ErrInvalidData = errors.New("ptstemmer: invalid binary data")
+    // ErrChecksum is returned when the checksum of binary data does not
+    // match its contents.
+    ErrChecksum = errors.New("ptstemmer: binary data checksum mismatch")
+    // ErrVersion is returned when binary data was encoded with an
+    // unsupported format version.
+    ErrVersion = errors.New("ptstemmer: unsupported binary data version")
+    // ErrKind is returned when binary data holds a different kind of
+    // value than the one being decoded.
+    ErrKind = errors.New("ptstemmer: unexpected kind of binary data")
+)
+
+// binaryWriter accumulates the payload of an encoded value.
+type binaryWriter struct {
+    buf bytes.Buffer
+}
+
+// Create a writer with the header for the given kind of value.
+func newBinaryWriter(kind byte) *binaryWriter {
+    w := new(binaryWriter)
+    w.buf.WriteString(binaryMagic)
+    w.putUvarint(binaryVersion)
+    w.buf.WriteByte(kind)
+    return w
+}
+
+// Write an unsigned integer in varint encoding.
+func (w *binaryWriter) putUvarint(v uint64) {
+    w.buf.Write(binary.AppendUvarint(nil, v))
+}
+
+// Write a signed integer in varint encoding.
+func (w *binaryWriter) putVarint(v int64) {
+    w.buf.Write(binary.AppendVarint(nil, v))
+}
+
+// Write a string prefixed by its length.
+func (w *binaryWriter) putString(s string) {
+    w.putUvarint(uint64(len(s)))
+    w.buf.WriteString(s)
+}
+
+// Write a boolean as a single byte.
+func (w *binaryWriter) putBool(b bool) {
+    if b {
+        w.buf.WriteByte(1)
+    } else {
+        w.buf.WriteByte(0)
+    }
+}
+
+// Write the keys of a set in sorted order, so that equal sets have the
+// same encoding.
+func (w *binaryWriter) putSet(set map[string]bool) {
+    keys := make([]string, 0, len(set))
+    for k := range set {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    w.putUvarint(uint64(len(keys)))
+    for _, k := range keys {
+        w.putString(k)
+    }
+}
+
+// Append the checksum and return the encoded bytes.
+func (w *binaryWriter) bytes() []byte {
+    sum := crc32.ChecksumIEEE(w.buf.Bytes())
+    return binary.BigEndian.AppendUint32(w.buf.Bytes(), sum)
+}
+
+// binaryReader decodes a payload written by binaryWriter. The first
+// decoding error is kept in err and every following read is ignored.
+type binaryReader struct {
+    data []byte
+    err  error
+}
+
+// Create a reader after validating the checksum and header of data.
+func newBinaryReader(data []byte, kind byte) (*binaryReader, error) {
+    if len(data) < len(binaryMagic)+4 {
+        return nil, ErrInvalidData
+    }
+
+    body := data[:len(data)-4]
+    sum := binary.BigEndian.Uint32(data[len(data)-4:])
+    if crc32.ChecksumIEEE(body) != sum {
+        return nil, ErrChecksum
+    }
+    if string(body[:len(binaryMagic)]) != binaryMagic {
+        return nil, ErrInvalidData
+    }
+
+    r := &binaryReader{data: body[len(binaryMagic):]}
+    version := r.uvarint()
+    k := r.byte()
+    if r.err != nil {
+        return nil, r.err
+    }
+    if version != binaryVersion {
+        return nil, ErrVersion
+    }
+    if k != kind {
+        return nil, ErrKind
+    }
+    return r, nil
+}
+
+// Read a single byte.
+func (r *binaryReader) byte() byte {
+    if r.err != nil {
+        return 0
+    }
+    if len(r.data) == 0 {
+        r.err = ErrInvalidData
+        return 0
+    }
+    b := r.data[0]
+    r.data = r.data[1:]
+    return b
+}
+
+// Read an unsigned integer written by putUvarint.
+func (r *binaryReader) uvarint() uint64 {
+    if r.err != nil {
+        return 0
+    }
+    v, n := binary.Uvarint(r.data)
+    if n <= 0 {
+        r.err = ErrInvalidData
+        return 0
+    }
+    r.data = r.data[n:]
+    return v
+}
+
+// Read a signed integer written by putVarint.
+func (r *binaryReader) varint() int64 {
+    if r.err != nil {
+        return 0
+    }
+    v, n := binary.Varint(r.data)
+    if n <= 0 {
+        r.err = ErrInvalidData
+        return 0
+    }
+    r.data = r.data[n:]
+    return v
+}
+
+// Read a string written by putString.
+func (r *binaryReader) string() string {
+    n := r.uvarint()
+    if r.err != nil {
+        return ""
+    }
+    if uint64(len(r.data)) < n {
+        r.err = ErrInvalidData
+        return ""
+    }
+    s := string(r.data[:n])
+    r.data = r.data[n:]
+    return s
+}
+
+// Read a boolean written by putBool.
+func (r *binaryReader) bool() bool {
+    return r.byte() != 0
+}
+
+// Read a set written by putSet.
+func (r *binaryReader) set() map[string]bool {
+    n := r.uvarint()
+    set := make(map[string]bool)
+    for i := uint64(0); i < n && r.err == nil; i++ {
+        set[r.string()] = true
+    }
+    return set
+}
+
+// Return the decoding error, or ErrInvalidData if there are unread
+// bytes left.
+func (r *binaryReader) close() error {
+    if r.err == nil && len(r.data) > 0 {
+        return ErrInvalidData
+    }
+    return r.err
+}
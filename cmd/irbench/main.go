@@ -0,0 +1,124 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Command irbench compares the retrieval effectiveness of analyzers over
+// a test collection. Documents and topics are read in the TREC format and
+// judgements in the TREC qrels format. Usage:
+//
+//      irbench -docs docs.trec -topics topics.trec -qrels qrels.txt
+//              [-analyzers none,porter,porter-dim,porter-prefix]
+package main
+
+import (
+    "flag"
+    "fmt"
+    "io"
+    "os"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer"
+    "github.com/tncardoso/ptstemmer/eval"
+)
+
+// Create the analyzer registered under the given name.
+func newAnalyzer(name string) (*ptstemmer.Analyzer, error) {
+    switch name {
+    case "none":
+        return ptstemmer.NewAnalyzer(nil), nil
+    case "porter":
+        return ptstemmer.NewAnalyzer(ptstemmer.NewPorterStemmer()), nil
+    case "porter-dim":
+        ps := ptstemmer.NewPorterStemmer()
+        ps.SetDiminutiveReducer(ptstemmer.NewDiminutiveReducer())
+        return ptstemmer.NewAnalyzer(ps), nil
+    case "porter-prefix":
+        ps := ptstemmer.NewPorterStemmer()
+        ps.SetPrefixStripper(ptstemmer.NewPrefixStripper())
+        return ptstemmer.NewAnalyzer(ps), nil
+    case "trunc5":
+        return ptstemmer.NewAnalyzer(eval.Truncate{N: 5}), nil
+    }
+    return nil, fmt.Errorf("unknown analyzer %q", name)
+}
+
+func main() {
+    docsPath := flag.String("docs", "", "documents in TREC format")
+    topicsPath := flag.String("topics", "", "topics in TREC format")
+    qrelsPath := flag.String("qrels", "", "relevance judgements in TREC format")
+    names := flag.String("analyzers", "none,porter,porter-dim,porter-prefix",
+        "comma separated analyzers to compare")
+    flag.Parse()
+
+    if *docsPath == "" || *topicsPath == "" || *qrelsPath == "" {
+        flag.Usage()
+        os.Exit(2)
+    }
+
+    analyzers := make([]eval.NamedAnalyzer, 0)
+    for _, name := range strings.Split(*names, ",") {
+        a, err := newAnalyzer(name)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        analyzers = append(analyzers, eval.NamedAnalyzer{Name: name, Analyzer: a})
+    }
+
+    if err := run(*docsPath, *topicsPath, *qrelsPath, analyzers); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+}
+
+// Read the test collection and write the report of the analyzers.
+func run(docsPath, topicsPath, qrelsPath string, analyzers []eval.NamedAnalyzer) error {
+    docs, err := readFile(docsPath, eval.ReadTRECDocuments)
+    if err != nil {
+        return err
+    }
+    topics, err := readFile(topicsPath, eval.ReadTRECTopics)
+    if err != nil {
+        return err
+    }
+    qrels, err := readFile(qrelsPath, eval.ReadQrels)
+    if err != nil {
+        return err
+    }
+
+    results := eval.Benchmark(docs, topics, qrels, analyzers)
+    return eval.WriteIRReport(os.Stdout, results)
+}
+
+// Open a file and parse it with the given reader function.
+func readFile[T any](path string, read func(io.Reader) (T, error)) (T, error) {
+    ip, err := os.Open(path)
+    if err != nil {
+        var zero T
+        return zero, err
+    }
+    defer ip.Close()
+    return read(ip)
+}
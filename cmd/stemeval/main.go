@@ -0,0 +1,133 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Command stemeval compares stemmers against gold conflation groups.
+//
+// The gold file holds one word per line followed by its group, usually
+// its lemma. Alternatively, a CoNLL-U treebank can be given, in which
+// case the lemmas of the treebank are used as gold groups and results
+// are reported for each part of speech. Usage:
+//
+//      stemeval -gold words.txt [-stemmers none,porter,trunc5]
+//      stemeval -conllu treebank.conllu [-stemmers none,porter,trunc5]
+package main
+
+import (
+    "flag"
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer"
+    "github.com/tncardoso/ptstemmer/eval"
+)
+
+// Create the stemmer registered under the given name.
+func newStemmer(name string) (ptstemmer.Stemmer, error) {
+    switch name {
+    case "none":
+        return eval.Identity{}, nil
+    case "porter":
+        return ptstemmer.NewPorterStemmer(), nil
+    case "porter-dim":
+        ps := ptstemmer.NewPorterStemmer()
+        ps.SetDiminutiveReducer(ptstemmer.NewDiminutiveReducer())
+        return ps, nil
+    case "porter-prefix":
+        ps := ptstemmer.NewPorterStemmer()
+        ps.SetPrefixStripper(ptstemmer.NewPrefixStripper())
+        return ps, nil
+    case "trunc4":
+        return eval.Truncate{N: 4}, nil
+    case "trunc5":
+        return eval.Truncate{N: 5}, nil
+    }
+    return nil, fmt.Errorf("unknown stemmer %q", name)
+}
+
+func main() {
+    goldPath := flag.String("gold", "", "gold file with word and group per line")
+    conlluPath := flag.String("conllu", "", "CoNLL-U treebank used as gold data")
+    names := flag.String("stemmers", "none,porter,porter-dim,porter-prefix,trunc5",
+        "comma separated stemmers to compare")
+    flag.Parse()
+
+    if (*goldPath == "") == (*conlluPath == "") {
+        flag.Usage()
+        os.Exit(2)
+    }
+
+    stemmers := make([]eval.NamedStemmer, 0)
+    for _, name := range strings.Split(*names, ",") {
+        s, err := newStemmer(name)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(2)
+        }
+        stemmers = append(stemmers, eval.NamedStemmer{Name: name, Stemmer: s})
+    }
+
+    var err error
+    if *conlluPath != "" {
+        err = conlluReport(*conlluPath, stemmers)
+    } else {
+        err = goldReport(*goldPath, stemmers)
+    }
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+}
+
+// Write the report of the stemmers over a gold file.
+func goldReport(path string, stemmers []eval.NamedStemmer) error {
+    ip, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer ip.Close()
+
+    gold, err := eval.ReadGold(ip)
+    if err != nil {
+        return err
+    }
+    return eval.WriteReport(os.Stdout, gold, stemmers)
+}
+
+// Write the per part of speech report of the stemmers over a treebank.
+func conlluReport(path string, stemmers []eval.NamedStemmer) error {
+    ip, err := os.Open(path)
+    if err != nil {
+        return err
+    }
+    defer ip.Close()
+
+    golds, err := eval.ReadCoNLLUGold(eval.NewCoNLLUReader(ip))
+    if err != nil {
+        return err
+    }
+    return eval.WritePOSReport(os.Stdout, golds, stemmers)
+}
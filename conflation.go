@@ -0,0 +1,165 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer/internal/counting"
+)
+
+// ConflationClasses groups the words of a vocabulary by stem. Each class
+// holds every known surface form of a stem, which allows expanding a
+// query word into all of its inflections when the index cannot be
+// stemmed.
+type ConflationClasses struct {
+    stemmer Stemmer                    // Stemmer that defines the classes
+    classes map[string]map[string]bool // Forms of each stem
+}
+
+// Create empty conflation classes for the stems produced by stemmer.
+func NewConflationClasses(stemmer Stemmer) *ConflationClasses {
+    c := new(ConflationClasses)
+    c.stemmer = stemmer
+    c.classes = make(map[string]map[string]bool)
+    return c
+}
+
+// Add a word to the class of its stem. Words are stored in lower case.
+func (c *ConflationClasses) Add(word string) {
+    word = strings.ToLower(word)
+    c.addForm(c.stemmer.Stem(word), word)
+}
+
+// Add a form to the class of a stem.
+func (c *ConflationClasses) addForm(stem, form string) {
+    class, ok := c.classes[stem]
+    if !ok {
+        class = make(map[string]bool)
+        c.classes[stem] = class
+    }
+    class[form] = true
+}
+
+// AddVocabulary adds the first word of each line of a vocabulary file.
+// Other columns, such as the expected stems of testdata/ptstems.txt,
+// are ignored.
+func (c *ConflationClasses) AddVocabulary(r io.Reader) error {
+    sc := bufio.NewScanner(r)
+    for sc.Scan() {
+        fields := strings.Fields(sc.Text())
+        if len(fields) > 0 {
+            c.Add(fields[0])
+        }
+    }
+    return sc.Err()
+}
+
+// Class returns the sorted forms of a stem. If the stem is unknown, nil
+// is returned.
+func (c *ConflationClasses) Class(stem string) []string {
+    class, ok := c.classes[stem]
+    if !ok {
+        return nil
+    }
+
+    res := make([]string, 0, len(class))
+    for f := range class {
+        res = append(res, f)
+    }
+    sort.Strings(res)
+    return res
+}
+
+// Expand returns every known form sharing the stem of the word, sorted.
+// The lowercased word itself is always included, even if it is not in
+// the vocabulary.
+func (c *ConflationClasses) Expand(word string) []string {
+    word = strings.ToLower(word)
+    res := c.Class(c.stemmer.Stem(word))
+
+    i := sort.SearchStrings(res, word)
+    if i == len(res) || res[i] != word {
+        res = append(res, "")
+        copy(res[i+1:], res[i:])
+        res[i] = word
+    }
+    return res
+}
+
+// Stems returns the sorted stems of all classes.
+func (c *ConflationClasses) Stems() []string {
+    res := make([]string, 0, len(c.classes))
+    for s := range c.classes {
+        res = append(res, s)
+    }
+    sort.Strings(res)
+    return res
+}
+
+// Len returns the number of classes.
+func (c *ConflationClasses) Len() int {
+    return len(c.classes)
+}
+
+// WriteTo implements the io.WriterTo interface. Classes are written one
+// per line, sorted by stem, in the format
+//
+//      [stem] TAB [form] SPACE [form] ...
+func (c *ConflationClasses) WriteTo(w io.Writer) (int64, error) {
+    bw := bufio.NewWriter(w)
+    total := int64(0)
+    for _, s := range c.Stems() {
+        n, err := fmt.Fprintf(bw, "%s\t%s\n", s, strings.Join(c.Class(s), " "))
+        total += int64(n)
+        if err != nil {
+            return total, err
+        }
+    }
+    return total, bw.Flush()
+}
+
+// ReadFrom implements the io.ReaderFrom interface. Classes written by
+// WriteTo are merged with the current ones.
+func (c *ConflationClasses) ReadFrom(r io.Reader) (int64, error) {
+    cr := &counting.Reader{R: r}
+    sc := bufio.NewScanner(cr)
+    for line := 1; sc.Scan(); line++ {
+        stem, forms, ok := strings.Cut(sc.Text(), "\t")
+        if !ok {
+            return cr.N, fmt.Errorf("ptstemmer: line %d: invalid conflation class", line)
+        }
+        for _, f := range strings.Fields(forms) {
+            c.addForm(stem, f)
+        }
+    }
+    return cr.N, sc.Err()
+}
@@ -0,0 +1,93 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bytes"
+    "os"
+    "strings"
+    "testing"
+)
+
+// TestExpand checks if words are expanded to the forms of the
+// vocabulary in testdata/ptstems.txt sharing their stem.
+func TestExpand(t *testing.T) {
+    ip, err := os.Open("testdata/ptstems.txt")
+    if err != nil {
+        t.Fatalf("Could not open test file: testdata/ptstems.txt")
+    }
+    defer ip.Close()
+
+    c := NewConflationClasses(NewPorterStemmer())
+    if err := c.AddVocabulary(ip); err != nil {
+        t.Fatalf("Could not read vocabulary: %v\n", err)
+    }
+
+    forms := c.Expand("Ajudei")
+    for _, f := range []string{"ajudei", "ajuda", "ajudaram", "ajudou"} {
+        found := false
+        for _, e := range forms {
+            found = found || e == f
+        }
+        if !found {
+            t.Errorf("Missing expansion. word= ajudei form= %s\n", f)
+        }
+    }
+
+    class := c.Class("ajud")
+    if len(class) != 18 || len(forms) != 19 {
+        t.Errorf("Wrong class size. expected= 18 actual= %d\n", len(class))
+    }
+    if c.Class("xyz") != nil {
+        t.Errorf("Unknown stem has a class\n")
+    }
+}
+
+// TestConflationPersistence checks if classes survive a round trip.
+func TestConflationPersistence(t *testing.T) {
+    ps := NewPorterStemmer()
+    c := NewConflationClasses(ps)
+    for _, w := range []string{"governo", "governar", "casa", "casas"} {
+        c.Add(w)
+    }
+
+    var buf bytes.Buffer
+    if _, err := c.WriteTo(&buf); err != nil {
+        t.Fatalf("Could not write classes: %v\n", err)
+    }
+    if buf.String() != "cas\tcasa casas\ngovern\tgovernar governo\n" {
+        t.Errorf("Wrong encoding: %q\n", buf.String())
+    }
+
+    loaded := NewConflationClasses(ps)
+    if _, err := loaded.ReadFrom(&buf); err != nil {
+        t.Fatalf("Could not read classes: %v\n", err)
+    }
+    if loaded.Len() != 2 || strings.Join(loaded.Expand("governo"), " ") != "governar governo" {
+        t.Errorf("Wrong classes after loading: %v\n", loaded.Stems())
+    }
+}
@@ -0,0 +1,143 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+)
+
+// DefaultContractions maps common portuguese contractions to the words
+// they are made of. Contractions of 'de', 'em', 'por' and 'a' with
+// articles, demonstratives and pronouns are included.
+var DefaultContractions = map[string][]string{
+    // de + article
+    "do": {"de", "o"}, "da": {"de", "a"},
+    "dos": {"de", "os"}, "das": {"de", "as"},
+    "dum": {"de", "um"}, "duma": {"de", "uma"},
+    "duns": {"de", "uns"}, "dumas": {"de", "umas"},
+    // de + pronoun
+    "dele": {"de", "ele"}, "dela": {"de", "ela"},
+    "deles": {"de", "eles"}, "delas": {"de", "elas"},
+    "deste": {"de", "este"}, "desta": {"de", "esta"},
+    "destes": {"de", "estes"}, "destas": {"de", "estas"},
+    "desse": {"de", "esse"}, "dessa": {"de", "essa"},
+    "desses": {"de", "esses"}, "dessas": {"de", "essas"},
+    "daquele": {"de", "aquele"}, "daquela": {"de", "aquela"},
+    "daqueles": {"de", "aqueles"}, "daquelas": {"de", "aquelas"},
+    "disto": {"de", "isto"}, "disso": {"de", "isso"},
+    "daquilo": {"de", "aquilo"},
+    "daqui": {"de", "aqui"}, "daí": {"de", "aí"}, "dali": {"de", "ali"},
+    "doutro": {"de", "outro"}, "doutra": {"de", "outra"},
+    "doutros": {"de", "outros"}, "doutras": {"de", "outras"},
+    // em + article
+    "no": {"em", "o"}, "na": {"em", "a"},
+    "nos": {"em", "os"}, "nas": {"em", "as"},
+    "num": {"em", "um"}, "numa": {"em", "uma"},
+    "nuns": {"em", "uns"}, "numas": {"em", "umas"},
+    // em + pronoun
+    "nele": {"em", "ele"}, "nela": {"em", "ela"},
+    "neles": {"em", "eles"}, "nelas": {"em", "elas"},
+    "neste": {"em", "este"}, "nesta": {"em", "esta"},
+    "nestes": {"em", "estes"}, "nestas": {"em", "estas"},
+    "nesse": {"em", "esse"}, "nessa": {"em", "essa"},
+    "nesses": {"em", "esses"}, "nessas": {"em", "essas"},
+    "naquele": {"em", "aquele"}, "naquela": {"em", "aquela"},
+    "naqueles": {"em", "aqueles"}, "naquelas": {"em", "aquelas"},
+    "nisto": {"em", "isto"}, "nisso": {"em", "isso"},
+    "naquilo": {"em", "aquilo"},
+    "noutro": {"em", "outro"}, "noutra": {"em", "outra"},
+    "noutros": {"em", "outros"}, "noutras": {"em", "outras"},
+    // por + article
+    "pelo": {"por", "o"}, "pela": {"por", "a"},
+    "pelos": {"por", "os"}, "pelas": {"por", "as"},
+    // a + article and demonstratives
+    "ao": {"a", "o"}, "aos": {"a", "os"},
+    "à": {"a", "a"}, "às": {"a", "as"},
+    "àquele": {"a", "aquele"}, "àquela": {"a", "aquela"},
+    "àqueles": {"a", "aqueles"}, "àquelas": {"a", "aquelas"},
+    "àquilo": {"a", "aquilo"},
+}
+
+// ContractionFilter expands contractions into the words they are made
+// of, e.g. "daquele" becomes "de" "aquele". Every expanded token keeps
+// the offsets of the original contraction.
+type ContractionFilter struct {
+    table map[string][]string // Contraction to component words
+}
+
+// Create a contraction filter using the given table. Keys must be in
+// lower case. If table is nil, DefaultContractions is used.
+func NewContractionFilter(table map[string][]string) *ContractionFilter {
+    f := new(ContractionFilter)
+    if table == nil {
+        table = DefaultContractions
+    }
+
+    f.table = make(map[string][]string, len(table))
+    for k, v := range table {
+        f.table[k] = v
+    }
+    return f
+}
+
+// Add a contraction to the filter table, replacing any previous
+// expansion. Returns the filter to allow chained calls.
+func (f *ContractionFilter) Add(contraction string, words ...string) *ContractionFilter {
+    f.table[strings.ToLower(contraction)] = words
+    return f
+}
+
+// Remove a contraction from the filter table.
+func (f *ContractionFilter) Remove(contraction string) {
+    delete(f.table, strings.ToLower(contraction))
+}
+
+// Expand returns the words that compose a contraction. If the word is
+// not a known contraction, nil is returned. Lookup is case insensitive.
+func (f *ContractionFilter) Expand(word string) []string {
+    return f.table[strings.ToLower(word)]
+}
+
+// Filter expands every known contraction in the token stream.
+func (f *ContractionFilter) Filter(tokens []Token) []Token {
+    res := make([]Token, 0, len(tokens))
+    for _, t := range tokens {
+        var words []string
+        if t.Type == TokenWord {
+            words = f.Expand(t.Text)
+        }
+        if words == nil {
+            res = append(res, t)
+            continue
+        }
+
+        for _, w := range words {
+            res = append(res, Token{Text: w, Start: t.Start, End: t.End})
+        }
+    }
+    return res
+}
@@ -0,0 +1,86 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestContractionFilter checks if contractions are expanded and keep the
+// offsets of the original word.
+func TestContractionFilter(t *testing.T) {
+    text := "Gosto daquele livro, disso e do outro."
+    expected := []Token{
+        {"Gosto", 0, 5, TokenWord},
+        {"de", 6, 13, TokenWord},
+        {"aquele", 6, 13, TokenWord},
+        {"livro", 14, 19, TokenWord},
+        {"de", 21, 26, TokenWord},
+        {"isso", 21, 26, TokenWord},
+        {"e", 27, 28, TokenWord},
+        {"de", 29, 31, TokenWord},
+        {"o", 29, 31, TokenWord},
+        {"outro", 32, 37, TokenWord},
+    }
+
+    f := NewContractionFilter(nil)
+    tokens := f.Filter(Tokenize(text))
+    if len(tokens) != len(expected) {
+        t.Fatalf("Wrong number of tokens. expected= %v actual= %v\n",
+            expected, tokens)
+    }
+
+    for i := range tokens {
+        if tokens[i] != expected[i] {
+            t.Errorf("Wrong token. expected= %v actual= %v\n",
+                expected[i], tokens[i])
+        }
+    }
+}
+
+// TestContractionTable checks if the contraction table can be changed
+// without modifying the default table.
+func TestContractionTable(t *testing.T) {
+    f := NewContractionFilter(nil)
+    f.Add("pra", "para", "a").Remove("no")
+
+    if w := f.Expand("Pra"); len(w) != 2 || w[0] != "para" || w[1] != "a" {
+        t.Errorf("Wrong expansion. expected= [para a] actual= %v\n", w)
+    }
+    if w := f.Expand("no"); w != nil {
+        t.Errorf("Removed contraction expanded. actual= %v\n", w)
+    }
+    if w := f.Expand("àquela"); len(w) != 2 || w[1] != "aquela" {
+        t.Errorf("Wrong expansion. expected= [a aquela] actual= %v\n", w)
+    }
+    if _, ok := DefaultContractions["pra"]; ok {
+        t.Errorf("Default table was modified\n")
+    }
+    if _, ok := DefaultContractions["no"]; !ok {
+        t.Errorf("Default table was modified\n")
+    }
+}
@@ -0,0 +1,329 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package dedup detects near-duplicate documents. Documents are reduced
+// to shingles of consecutive stemmed words, without stop words, so that
+// rewording a text with other inflections of the same words does not
+// hide a copy. Shingle sets are compared with MinHash signatures indexed
+// by locality sensitive hashing, or with SimHash fingerprints.
+package dedup
+
+import (
+    "hash/fnv"
+    "math/bits"
+    "math/rand"
+    "sort"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Shingles returns the distinct hashes of every sequence of k consecutive
+// terms produced by the analyzer. Texts with fewer than k terms produce
+// a single shingle with all their terms.
+func Shingles(a *ptstemmer.Analyzer, text string, k int) []uint64 {
+    terms := a.Terms(text)
+    if len(terms) == 0 {
+        return []uint64{}
+    }
+    if len(terms) < k {
+        k = len(terms)
+    }
+
+    seen := make(map[uint64]bool)
+    res := make([]uint64, 0, len(terms)-k+1)
+    for i := 0; i+k <= len(terms); i++ {
+        h := fnv.New64a()
+        h.Write([]byte(strings.Join(terms[i:i+k], " ")))
+        s := h.Sum64()
+        if !seen[s] {
+            seen[s] = true
+            res = append(res, s)
+        }
+    }
+    return res
+}
+
+// Jaccard returns the exact Jaccard similarity of two shingle sets.
+func Jaccard(a, b []uint64) float64 {
+    set := make(map[uint64]bool, len(a))
+    for _, s := range a {
+        set[s] = true
+    }
+
+    inter := 0
+    union := len(set)
+    for _, s := range b {
+        if set[s] {
+            inter++
+            delete(set, s)
+        } else {
+            union++
+        }
+    }
+    if union == 0 {
+        return 1
+    }
+    return float64(inter) / float64(union)
+}
+
+// Mix the bits of a value with the splitmix64 finalizer.
+func mix(x uint64) uint64 {
+    x ^= x >> 30
+    x *= 0xbf58476d1ce4e5b9
+    x ^= x >> 27
+    x *= 0x94d049bb133111eb
+    x ^= x >> 31
+    return x
+}
+
+// MinHasher computes MinHash signatures of shingle sets. Signatures of
+// the same hasher can be compared with EstimateJaccard.
+type MinHasher struct {
+    seeds []uint64 // Seed of each hash function
+}
+
+// Create a hasher with n hash functions derived from seed.
+func NewMinHasher(n int, seed int64) *MinHasher {
+    m := new(MinHasher)
+    rng := rand.New(rand.NewSource(seed))
+    m.seeds = make([]uint64, n)
+    for i := range m.seeds {
+        m.seeds[i] = rng.Uint64()
+    }
+    return m
+}
+
+// Signature returns, for each hash function, the minimum hash of the
+// shingles. An empty shingle set has no signature and returns nil, so
+// that texts without terms are not similar to each other.
+func (m *MinHasher) Signature(shingles []uint64) []uint64 {
+    if len(shingles) == 0 {
+        return nil
+    }
+    sig := make([]uint64, len(m.seeds))
+    for i, seed := range m.seeds {
+        min := ^uint64(0)
+        for _, s := range shingles {
+            if h := mix(s ^ seed); h < min {
+                min = h
+            }
+        }
+        sig[i] = min
+    }
+    return sig
+}
+
+// EstimateJaccard estimates the Jaccard similarity of two shingle sets
+// from their MinHash signatures, as the fraction of equal positions.
+func EstimateJaccard(a, b []uint64) float64 {
+    if len(a) != len(b) || len(a) == 0 {
+        return 0
+    }
+
+    equal := 0
+    for i := range a {
+        if a[i] == b[i] {
+            equal++
+        }
+    }
+    return float64(equal) / float64(len(a))
+}
+
+// SimHash returns a 64 bit fingerprint of a shingle set. Similar sets
+// have fingerprints with a small Hamming distance.
+func SimHash(shingles []uint64) uint64 {
+    var counts [64]int
+    for _, s := range shingles {
+        h := mix(s)
+        for i := 0; i < 64; i++ {
+            if h&(1<<uint(i)) != 0 {
+                counts[i]++
+            } else {
+                counts[i]--
+            }
+        }
+    }
+
+    res := uint64(0)
+    for i, c := range counts {
+        if c > 0 {
+            res |= 1 << uint(i)
+        }
+    }
+    return res
+}
+
+// HammingDistance returns the number of different bits of two
+// fingerprints.
+func HammingDistance(a, b uint64) int {
+    return bits.OnesCount64(a ^ b)
+}
+
+// LSH indexes MinHash signatures by bands, so that signatures agreeing on
+// all rows of at least one band are retrieved as candidates. Signatures
+// must have bands*rows values.
+type LSH struct {
+    bands   int                   // Number of bands
+    rows    int                   // Signature values in each band
+    buckets []map[uint64][]string // Documents of each band hash
+}
+
+// Create an empty LSH index.
+func NewLSH(bands, rows int) *LSH {
+    l := new(LSH)
+    l.bands = bands
+    l.rows = rows
+    l.buckets = make([]map[uint64][]string, bands)
+    for i := range l.buckets {
+        l.buckets[i] = make(map[uint64][]string)
+    }
+    return l
+}
+
+// Hash the rows of a band of a signature.
+func (l *LSH) bandHash(sig []uint64, band int) uint64 {
+    h := uint64(band)
+    for _, v := range sig[band*l.rows : (band+1)*l.rows] {
+        h = mix(h ^ v)
+    }
+    return h
+}
+
+// Add a document signature to the index. Empty signatures are not
+// indexed.
+func (l *LSH) Add(id string, sig []uint64) {
+    if len(sig) == 0 {
+        return
+    }
+    for b := 0; b < l.bands; b++ {
+        h := l.bandHash(sig, b)
+        l.buckets[b][h] = append(l.buckets[b][h], id)
+    }
+}
+
+// Remove a document signature from the index.
+func (l *LSH) Remove(id string, sig []uint64) {
+    if len(sig) == 0 {
+        return
+    }
+    for b := 0; b < l.bands; b++ {
+        h := l.bandHash(sig, b)
+        ids := l.buckets[b][h]
+        for i := range ids {
+            if ids[i] == id {
+                ids = append(ids[:i], ids[i+1:]...)
+                break
+            }
+        }
+        if len(ids) == 0 {
+            delete(l.buckets[b], h)
+        } else {
+            l.buckets[b][h] = ids
+        }
+    }
+}
+
+// Candidates returns the sorted identifiers of documents sharing at
+// least one band with the signature. An empty signature has no
+// candidates.
+func (l *LSH) Candidates(sig []uint64) []string {
+    seen := make(map[string]bool)
+    for b := 0; b < l.bands && len(sig) > 0; b++ {
+        for _, id := range l.buckets[b][l.bandHash(sig, b)] {
+            seen[id] = true
+        }
+    }
+
+    res := make([]string, 0, len(seen))
+    for id := range seen {
+        res = append(res, id)
+    }
+    sort.Strings(res)
+    return res
+}
+
+// Duplicate is a document similar to a query document.
+type Duplicate struct {
+    ID         string  // Document identifier
+    Similarity float64 // Estimated Jaccard similarity
+}
+
+// Detector finds near-duplicates among added documents. It combines
+// stemmed shingles, MinHash signatures and an LSH index.
+type Detector struct {
+    analyzer   *ptstemmer.Analyzer // Analyzer producing shingle terms
+    shingle    int                 // Terms per shingle
+    hasher     *MinHasher          // Signature hash functions
+    lsh        *LSH                // Candidate index
+    signatures map[string][]uint64 // Signature of each document
+    Threshold  float64             // Minimum similarity of duplicates
+}
+
+// Create a detector using the standard analyzer with a PorterStemmer,
+// shingles of 3 terms and 128 hash functions split in 32 bands.
+func NewDetector() *Detector {
+    d := new(Detector)
+    d.analyzer = ptstemmer.NewAnalyzer(ptstemmer.NewPorterStemmer())
+    d.shingle = 3
+    d.hasher = NewMinHasher(128, 1)
+    d.lsh = NewLSH(32, 4)
+    d.signatures = make(map[string][]uint64)
+    d.Threshold = 0.5
+    return d
+}
+
+// Signature returns the MinHash signature of a text.
+func (d *Detector) Signature(text string) []uint64 {
+    return d.hasher.Signature(Shingles(d.analyzer, text, d.shingle))
+}
+
+// Add a document, replacing any document with the same identifier.
+func (d *Detector) Add(id, text string) {
+    if old, ok := d.signatures[id]; ok {
+        d.lsh.Remove(id, old)
+    }
+    sig := d.Signature(text)
+    d.signatures[id] = sig
+    d.lsh.Add(id, sig)
+}
+
+// Duplicates returns the added documents whose estimated similarity to
+// the text is at least Threshold, most similar first.
+func (d *Detector) Duplicates(text string) []Duplicate {
+    sig := d.Signature(text)
+    res := make([]Duplicate, 0)
+    for _, id := range d.lsh.Candidates(sig) {
+        if s := EstimateJaccard(sig, d.signatures[id]); s >= d.Threshold {
+            res = append(res, Duplicate{ID: id, Similarity: s})
+        }
+    }
+
+    sort.SliceStable(res, func(i, j int) bool {
+        return res[i].Similarity > res[j].Similarity
+    })
+    return res
+}
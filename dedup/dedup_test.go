@@ -0,0 +1,123 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package dedup
+
+import (
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Stories used by the tests. The rewrite changes inflections and stop
+// words of the original, the other story is unrelated.
+const (
+    original = "O governo federal anunciou nesta segunda-feira novas " +
+        "medidas para conter a inflação e reduzir os juros cobrados " +
+        "pelos bancos públicos nas linhas de crédito imobiliário."
+    rewrite = "Governo federal anuncia, nesta segunda-feira, nova medida " +
+        "para conter inflação e reduzir juros cobrados por bancos " +
+        "públicos em linha de crédito imobiliário."
+    other = "Fortes chuvas atingiram o litoral paulista durante o fim de " +
+        "semana e deixaram centenas de famílias desalojadas na região."
+)
+
+// TestShingles checks if inflected rewrites share their shingles.
+func TestShingles(t *testing.T) {
+    a := ptstemmer.NewAnalyzer(ptstemmer.NewPorterStemmer())
+
+    s1 := Shingles(a, original, 3)
+    s2 := Shingles(a, rewrite, 3)
+    if j := Jaccard(s1, s2); j != 1 {
+        t.Errorf("Wrong similarity. expected= 1 actual= %f\n", j)
+    }
+    if j := Jaccard(s1, Shingles(a, other, 3)); j != 0 {
+        t.Errorf("Wrong similarity. expected= 0 actual= %f\n", j)
+    }
+
+    none := ptstemmer.NewAnalyzer(nil)
+    if j := Jaccard(Shingles(none, original, 3), Shingles(none, rewrite, 3)); j > 0.5 {
+        t.Errorf("Unstemmed shingles should differ. similarity= %f\n", j)
+    }
+
+    if s := Shingles(a, "governo", 3); len(s) != 1 {
+        t.Errorf("Short texts should have one shingle: %v\n", s)
+    }
+}
+
+// TestMinHash checks if signatures estimate the Jaccard similarity.
+func TestMinHash(t *testing.T) {
+    a := make([]uint64, 100)
+    b := make([]uint64, 100)
+    for i := range a {
+        a[i] = uint64(i)
+        b[i] = uint64(i + 50)
+    }
+
+    m := NewMinHasher(256, 7)
+    exact := Jaccard(a, b)
+    estimate := EstimateJaccard(m.Signature(a), m.Signature(b))
+    if estimate < exact-0.1 || estimate > exact+0.1 {
+        t.Errorf("Wrong estimate. expected= %f actual= %f\n", exact, estimate)
+    }
+
+    if HammingDistance(SimHash(a), SimHash(a)) != 0 ||
+        HammingDistance(SimHash(a), SimHash(b)) == 0 {
+        t.Errorf("Wrong SimHash distances\n")
+    }
+}
+
+// TestDetector checks if rewritten stories are found as duplicates.
+func TestDetector(t *testing.T) {
+    d := NewDetector()
+    d.Add("original", original)
+    d.Add("other", other)
+
+    dups := d.Duplicates(rewrite)
+    if len(dups) != 1 || dups[0].ID != "original" || dups[0].Similarity != 1 {
+        t.Errorf("Wrong duplicates: %v\n", dups)
+    }
+
+    d.Add("original", other)
+    if dups := d.Duplicates(rewrite); len(dups) != 0 {
+        t.Errorf("Replaced document is still a duplicate: %v\n", dups)
+    }
+    if dups := d.Duplicates(other); len(dups) != 2 {
+        t.Errorf("Wrong duplicates: %v\n", dups)
+    }
+
+    d.Add("a", "e de que")
+    if dups := d.Duplicates("o a ao"); len(dups) != 0 {
+        t.Errorf("Texts without terms should have no duplicates: %v\n", dups)
+    }
+    if sig := d.Signature("e de que"); sig != nil {
+        t.Errorf("Text without terms should have no signature: %v\n", sig)
+    }
+    d.Add("a", original)
+    if dups := d.Duplicates(rewrite); len(dups) != 1 || dups[0].ID != "a" {
+        t.Errorf("Wrong duplicates after replacing an empty document: %v\n", dups)
+    }
+}
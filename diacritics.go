@@ -0,0 +1,259 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "io"
+    "sort"
+    "strings"
+    "unicode"
+)
+
+// Letter of each lowercase portuguese letter with a diacritic.
+var diacriticBase = map[rune]rune{
+    'á': 'a', 'à': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a',
+    'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+    'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+    'ó': 'o', 'ò': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o',
+    'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+    'ç': 'c', 'ñ': 'n',
+}
+
+// StripDiacritics returns the text without accents and cedillas. Letter
+// case is preserved.
+func StripDiacritics(text string) string {
+    return strings.Map(func(r rune) rune {
+        if b, ok := diacriticBase[unicode.ToLower(r)]; ok {
+            if unicode.IsUpper(r) {
+                return unicode.ToUpper(b)
+            }
+            return b
+        }
+        return r
+    }, text)
+}
+
+// Common words that are always written with diacritics. Words with an
+// unaccented homograph, such as "é" and "e", are left to the lexicon.
+var defaultAccentedWords = []string{
+    "não", "você", "vocês", "também", "já", "até", "só", "então", "há",
+    "três", "mês", "após", "além", "porém", "café", "estão", "pé", "fé",
+    "ninguém", "alguém", "mãe", "mão", "irmão", "informação",
+}
+
+// Default suffix rules, mapping unaccented endings to accented ones.
+var defaultDiacriticRules = []struct {
+    suffix       string
+    replacements []string
+}{
+    {"cao", []string{"ção"}},
+    {"coes", []string{"ções"}},
+    {"sao", []string{"são"}},
+    {"soes", []string{"sões"}},
+    {"ao", []string{"ão"}},
+    {"oes", []string{"ões"}},
+    {"aes", []string{"ães"}},
+    {"avel", []string{"ável"}},
+    {"ivel", []string{"ível"}},
+    {"aveis", []string{"áveis"}},
+    {"iveis", []string{"íveis"}},
+    {"encia", []string{"ência"}},
+    {"encias", []string{"ências"}},
+    {"ancia", []string{"ância"}},
+    {"ancias", []string{"âncias"}},
+    {"ario", []string{"ário"}},
+    {"arios", []string{"ários"}},
+    {"orio", []string{"ório"}},
+    {"orios", []string{"órios"}},
+    {"logico", []string{"lógico"}},
+    {"logica", []string{"lógica"}},
+}
+
+// Candidate is a possible accented form of a word.
+type Candidate struct {
+    Form  string // Accented form
+    Count int    // Occurrences in the lexicon, 0 if suggested by a rule
+}
+
+// Restoration is the result of restoring the diacritics of a word.
+type Restoration struct {
+    Word       string      // Most plausible form, in the case of the input
+    Candidates []Candidate // Plausible forms, most plausible first
+    Ambiguous  bool        // True if more than one form is plausible
+}
+
+// Restorer restores the diacritics of unaccented words. Known words are
+// restored to their most frequent form in a lexicon, and unknown words
+// by rules matching their endings, such as -cao to -ção.
+type Restorer struct {
+    lexicon        map[string]map[string]int // Forms of each unaccented word
+    suffixes       *suffixTree               // Rule endings
+    replacements   [][]string                // Replacements of each rule
+    AmbiguityRatio float64                   // Relative frequency of ambiguous forms
+}
+
+// Create a restorer with the default rules and a few common words. The
+// lexicon should be trained with accented text.
+func NewRestorer() *Restorer {
+    r := new(Restorer)
+    r.lexicon = make(map[string]map[string]int)
+    r.suffixes = newSuffixTree()
+    r.AmbiguityRatio = 0.1
+    for _, w := range defaultAccentedWords {
+        r.Add(w)
+    }
+    for _, rule := range defaultDiacriticRules {
+        r.AddRule(rule.suffix, rule.replacements...)
+    }
+    return r
+}
+
+// AddRule adds a rule restoring an unaccented ending to the replacements.
+// A rule without replacements keeps matching words unchanged, blocking
+// shorter rules.
+func (r *Restorer) AddRule(suffix string, replacements ...string) *Restorer {
+    suffix = strings.ToLower(StripDiacritics(suffix))
+    if r.suffixes.Contains(suffix) {
+        _, group := r.suffixes.LongestSuffix(suffix)
+        r.replacements[group] = replacements
+        return r
+    }
+    r.suffixes.Add(suffix, len(r.replacements))
+    r.replacements = append(r.replacements, replacements)
+    return r
+}
+
+// Add a form occurrence to the lexicon.
+func (r *Restorer) Add(word string) {
+    r.AddCount(word, 1)
+}
+
+// AddCount adds n occurrences of a form to the lexicon.
+func (r *Restorer) AddCount(word string, n int) {
+    word = strings.ToLower(word)
+    key := StripDiacritics(word)
+    forms, ok := r.lexicon[key]
+    if !ok {
+        forms = make(map[string]int)
+        r.lexicon[key] = forms
+    }
+    forms[word] += n
+}
+
+// Train adds the words of an accented text to the lexicon.
+func (r *Restorer) Train(rd io.Reader) error {
+    br := bufio.NewReader(rd)
+    for {
+        l, err := br.ReadString('\n')
+        for _, t := range Tokenize(l) {
+            r.Add(t.Text)
+        }
+        if err == io.EOF {
+            return nil
+        } else if err != nil {
+            return err
+        }
+    }
+}
+
+// Return the candidate forms of a lowercase unaccented word.
+func (r *Restorer) candidates(key string) []Candidate {
+    if forms, ok := r.lexicon[key]; ok {
+        res := make([]Candidate, 0, len(forms))
+        for f, c := range forms {
+            res = append(res, Candidate{Form: f, Count: c})
+        }
+        sort.Slice(res, func(i, j int) bool {
+            if res[i].Count != res[j].Count {
+                return res[i].Count > res[j].Count
+            }
+            return res[i].Form < res[j].Form
+        })
+        return res
+    }
+
+    suffix, group := r.suffixes.LongestSuffix(key)
+    if group < 0 || len(suffix) == len(key) {
+        return nil
+    }
+    res := make([]Candidate, 0, len(r.replacements[group]))
+    for _, repl := range r.replacements[group] {
+        res = append(res, Candidate{Form: key[:len(key)-len(suffix)] + repl})
+    }
+    return res
+}
+
+// Return form with the letter case of word, rune by rune.
+func matchCase(word, form string) string {
+    w := []rune(word)
+    f := []rune(form)
+    if len(w) != len(f) {
+        return form
+    }
+    for i := range f {
+        if unicode.IsUpper(w[i]) {
+            f[i] = unicode.ToUpper(f[i])
+        }
+    }
+    return string(f)
+}
+
+// Restore returns the most plausible accented form of a word. Words that
+// already have diacritics, or have no candidate, are kept.
+func (r *Restorer) Restore(word string) Restoration {
+    key := strings.ToLower(word)
+    if StripDiacritics(key) != key {
+        return Restoration{Word: word, Candidates: []Candidate{{Form: key}}}
+    }
+
+    cands := r.candidates(key)
+    if len(cands) == 0 {
+        return Restoration{Word: word, Candidates: []Candidate{}}
+    }
+
+    res := Restoration{Word: matchCase(word, cands[0].Form), Candidates: cands}
+    if len(cands) > 1 {
+        best := float64(cands[0].Count)
+        res.Ambiguous = best == 0 || float64(cands[1].Count) >= r.AmbiguityRatio*best
+    }
+    return res
+}
+
+// RestoreText restores the diacritics of every word of a text, keeping
+// everything else unchanged.
+func (r *Restorer) RestoreText(text string) string {
+    var sb strings.Builder
+    last := 0
+    for _, t := range Tokenize(text) {
+        sb.WriteString(text[last:t.Start])
+        sb.WriteString(r.Restore(t.Text).Word)
+        last = t.End
+    }
+    sb.WriteString(text[last:])
+    return sb.String()
+}
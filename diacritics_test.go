@@ -0,0 +1,112 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// TestStripDiacritics checks if accents are removed preserving case.
+func TestStripDiacritics(t *testing.T) {
+    if s := StripDiacritics("Informação É Você"); s != "Informacao E Voce" {
+        t.Errorf("Wrong text: %s\n", s)
+    }
+}
+
+// TestRestore checks restoration by lexicon and by rules.
+func TestRestore(t *testing.T) {
+    r := NewRestorer()
+    err := r.Train(strings.NewReader("Ele é alto e forte.\nÉ verdade, é bom.\n" +
+        "A esta hora, está tudo fechado. Esta casa está vazia, e esta não."))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    tests := []struct {
+        word      string
+        expected  string
+        ambiguous bool
+    }{
+        {"nao", "não", false},
+        {"Voce", "Você", false},
+        {"NAO", "NÃO", false},
+        {"e", "é", true},
+        {"esta", "esta", true},
+        {"informacao", "informação", false},
+        {"revolucoes", "revoluções", false},
+        {"impossivel", "impossível", false},
+        {"agradaveis", "agradáveis", false},
+        {"frequencia", "frequência", false},
+        {"salario", "salário", false},
+        {"casa", "casa", false},
+        {"coração", "coração", false},
+        {"xyz", "xyz", false},
+    }
+
+    for _, test := range tests {
+        res := r.Restore(test.word)
+        if res.Word != test.expected || res.Ambiguous != test.ambiguous {
+            t.Errorf("Wrong restoration of %s. expected= %s/%v actual= %s/%v\n",
+                test.word, test.expected, test.ambiguous, res.Word, res.Ambiguous)
+        }
+    }
+
+    expected := []Candidate{{"é", 3}, {"e", 2}}
+    if c := r.Restore("e").Candidates; !reflect.DeepEqual(c, expected) {
+        t.Errorf("Wrong candidates. expected= %v actual= %v\n", expected, c)
+    }
+}
+
+// TestRestoreRules checks custom and blocking rules.
+func TestRestoreRules(t *testing.T) {
+    r := NewRestorer()
+    r.AddRule("ico", "ico", "íco")
+    r.AddRule("cacao")
+
+    if res := r.Restore("tico"); !res.Ambiguous || len(res.Candidates) != 2 {
+        t.Errorf("Rule with two forms should be ambiguous: %v\n", res)
+    }
+    if res := r.Restore("cacao"); res.Word != "cacao" {
+        t.Errorf("Blocking rule not applied: %v\n", res)
+    }
+    r.AddRule("cao", "çao")
+    if res := r.Restore("nacao"); res.Word != "naçao" {
+        t.Errorf("Rule not replaced: %v\n", res)
+    }
+}
+
+// TestRestoreText checks if only words are changed.
+func TestRestoreText(t *testing.T) {
+    r := NewRestorer()
+    text := "Voce nao viu a informacao? Ja esta disponivel."
+    expected := "Você não viu a informação? Já esta disponível."
+    if res := r.RestoreText(text); res != expected {
+        t.Errorf("Wrong text. expected= %s actual= %s\n", expected, res)
+    }
+}
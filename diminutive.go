@@ -0,0 +1,253 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode/utf8"
+)
+
+// DiminutiveReducer reduces diminutive and augmentative forms to the
+// word they derive from, e.g. "casinha" becomes "casa" and "carrão"
+// becomes "carro". It can be used as a standalone Stemmer or enabled in
+// a PorterStemmer with SetDiminutiveReducer.
+type DiminutiveReducer struct {
+    suffixes      *suffixTree       // Known suffixes, group 1 if checked
+    replacements  map[string]string // Replacement of each suffix
+    exceptions    map[string]bool   // Lexicalized words that are kept
+    bases         map[string]bool   // Known results of checked rules
+    MinStemLength int               // Minimum runes left after removal
+}
+
+// DiminutiveBases are common words derived by ambiguous augmentative and
+// diminutive suffixes, such as -ão, -aço and -ito. Those suffixes are
+// only removed when the result is one of these words.
+var DiminutiveBases = []string{
+    "amigo", "amiga", "bicho", "boca", "cabeça", "cachorro",
+    "café", "carro", "casa", "copo", "corpo", "festa", "gato",
+    "homem", "livro", "menino", "menina", "mulher", "nariz", "olho",
+    "pé", "porta", "prato", "rapaz", "rico", "rica", "sapato",
+}
+
+// Create a diminutive reducer with the default portuguese rules and
+// exceptions.
+func NewDiminutiveReducer() *DiminutiveReducer {
+    d := new(DiminutiveReducer)
+    d.suffixes = newSuffixTree()
+    d.replacements = make(map[string]string)
+    d.exceptions = make(map[string]bool)
+    d.bases = make(map[string]bool)
+    d.MinStemLength = 2
+
+    // Diminutives.
+    d.AddRule("inho", "o").AddRule("inhos", "os")
+    d.AddRule("inha", "a").AddRule("inhas", "as")
+    d.AddRule("zinho", "").AddRule("zinhos", "s")
+    d.AddRule("zinha", "").AddRule("zinhas", "s")
+
+    // Plurals of words ending in r, s or z take -es, and words ending in
+    // l take -is, e.g. "mulherzinhas" becomes "mulheres".
+    for _, c := range []string{"r", "s", "z"} {
+        d.AddRule(c+"zinhos", c+"es").AddRule(c+"zinhas", c+"es")
+    }
+    d.AddRule("lzinhos", "is").AddRule("lzinhas", "is")
+
+    // Augmentatives.
+    d.AddRule("rrão", "rro")
+
+    // Suffixes that end many ordinary words, as in "visita", "braço",
+    // "razão" and "falarão", are only removed when the result is a
+    // known base word.
+    d.AddCheckedRule("ito", "o").AddCheckedRule("itos", "os")
+    d.AddCheckedRule("ita", "a").AddCheckedRule("itas", "as")
+    d.AddCheckedRule("ão", "o").AddCheckedRule("zão", "").AddCheckedRule("zões", "s")
+    d.AddCheckedRule("aço", "o").AddCheckedRule("aços", "os")
+    d.AddCheckedRule("aça", "a").AddCheckedRule("aças", "as")
+    d.AddCheckedRule("ona", "a").AddCheckedRule("onas", "as")
+    d.AddCheckedRule("arão", "a").AddCheckedRule("arões", "as")
+    d.AddBase(DiminutiveBases...)
+
+    // Suffixes that look like augmentatives but are usually not. A rule
+    // replacing the suffix by itself keeps the word unchanged.
+    for _, s := range []string{"ção", "são", "rão", "dão", "ião",
+        "tão", "mão", "pão", "cão", "chão", "gão", "lão", "vão",
+        "iona", "dona", "lona", "rona", "tona"} {
+        d.AddRule(s, s)
+    }
+
+    d.AddException("vizinho", "vizinha", "padrinho", "madrinha", "caminho",
+        "caminha", "sozinho", "sozinha", "carinho", "espinho", "espinha",
+        "moinho", "focinho", "toucinho", "pergaminho", "adivinho",
+        "adivinha", "cozinha", "farinha", "galinha", "rainha", "marinha",
+        "sardinha", "campainha", "andorinha", "bainha", "ladainha",
+        "mesquinho", "mesquinha", "golfinho", "pinguinho", "passarinho",
+        "bonito", "bonita", "maldito", "maldita", "bendito", "bendita",
+        "favorito", "favorita", "infinito", "infinita", "gratuito",
+        "gratuita", "circuito", "fortuito", "intuito", "conflito",
+        "delito", "distrito", "perito", "perita", "erudito", "erudita",
+        "esquisito", "esquisita", "muito", "muita", "grito", "apito",
+        "frito", "frita", "atrito", "requisito", "quesito", "granito",
+        "mosquito", "cartão", "feijão", "algodão", "sabão", "pulmão",
+        "alemão", "irmão", "cidadão", "leão", "balcão", "botão", "portão",
+        "salão", "capitão", "padrão", "patrão", "limão", "espaço",
+        "pedaço", "cansaço", "terraço", "abraço", "palhaço", "ameaça",
+        "fumaça", "cachaça", "carcaça", "mordaça", "vidraça", "barcaça",
+        "trapaça", "desgraça", "pessoa", "madona", "patrona", "matrona",
+        "poltrona", "amazona", "sobrinho", "sobrinha")
+
+    return d
+}
+
+// Add a reduction rule. Words ending in suffix have it replaced by
+// replacement. Returns the reducer to allow chained calls.
+func (d *DiminutiveReducer) AddRule(suffix, replacement string) *DiminutiveReducer {
+    d.suffixes.Add(suffix, 0)
+    d.replacements[suffix] = replacement
+    return d
+}
+
+// Add a reduction rule that is only applied when the result, or its
+// singular, is a base word added with AddBase. Returns the reducer to
+// allow chained calls.
+func (d *DiminutiveReducer) AddCheckedRule(suffix, replacement string) *DiminutiveReducer {
+    d.suffixes.Add(suffix, 1)
+    d.replacements[suffix] = replacement
+    return d
+}
+
+// Add base words accepted as results of checked rules. Words are
+// compared in lower case and without diacritics. Returns the reducer to
+// allow chained calls.
+func (d *DiminutiveReducer) AddBase(words ...string) *DiminutiveReducer {
+    for _, w := range words {
+        d.bases[StripDiacritics(strings.ToLower(w))] = true
+    }
+    return d
+}
+
+// Return true if the word, or its singular, is a known base word.
+func (d *DiminutiveReducer) isBase(word string) bool {
+    word = StripDiacritics(strings.ToLower(word))
+    if d.bases[word] {
+        return true
+    }
+    if strings.HasSuffix(word, "es") && d.bases[strings.TrimSuffix(word, "es")] {
+        return true
+    }
+    return strings.HasSuffix(word, "s") && d.bases[strings.TrimSuffix(word, "s")]
+}
+
+// Add lexicalized words that should never be reduced. Plurals of the
+// given words are also kept. Returns the reducer to allow chained calls.
+func (d *DiminutiveReducer) AddException(words ...string) *DiminutiveReducer {
+    for _, w := range words {
+        d.exceptions[w] = true
+    }
+    return d
+}
+
+// Return true if the word, or its singular, is a known exception.
+func (d *DiminutiveReducer) isException(word string) bool {
+    if d.exceptions[word] {
+        return true
+    }
+    if strings.HasSuffix(word, "ões") {
+        return d.exceptions[strings.TrimSuffix(word, "ões")+"ão"]
+    }
+    return d.exceptions[strings.TrimSuffix(word, "s")]
+}
+
+// Reduce returns the word from which a diminutive or augmentative was
+// derived. Words that are exceptions, that would become shorter than
+// MinStemLength, whose remainder carries a stress mark or that match a
+// checked rule without giving a base word are returned unchanged.
+func (d *DiminutiveReducer) Reduce(word string) string {
+    if d.isException(word) {
+        return word
+    }
+
+    suffix, group := d.suffixes.LongestSuffix(word)
+    replacement := d.replacements[suffix]
+    if suffix == "" || suffix == replacement {
+        return word
+    }
+
+    // Diminutives and augmentatives move the stress to the suffix, so
+    // the remainder should not carry a stress mark.
+    stem := word[:len(word)-len(suffix)]
+    if utf8.RuneCountInString(stem) < d.MinStemLength ||
+        strings.ContainsAny(stem, "áéíóúâêô") {
+        return word
+    }
+
+    if group == 1 && !d.isBase(stem+replacement) {
+        return word
+    }
+    return stem + replacement
+}
+
+// Stem implements the Stemmer interface, reducing the word without any
+// further stemming.
+func (d *DiminutiveReducer) Stem(word string) string {
+    return d.Reduce(word)
+}
+
+// Write the rules, exceptions, base words and settings of the reducer.
+func (d *DiminutiveReducer) encode(w *binaryWriter) {
+    w.putUvarint(uint64(len(d.replacements)))
+    d.suffixes.trie.Walk(func(suffix string, group int) bool {
+        w.putString(suffix)
+        w.putString(d.replacements[suffix])
+        w.putBool(group == 1)
+        return true
+    })
+    w.putSet(d.exceptions)
+    w.putSet(d.bases)
+    w.putVarint(int64(d.MinStemLength))
+}
+
+// Create a reducer from the data written by encode.
+func decodeDiminutiveReducer(r *binaryReader) *DiminutiveReducer {
+    d := new(DiminutiveReducer)
+    d.suffixes = newSuffixTree()
+    d.replacements = make(map[string]string)
+
+    n := r.uvarint()
+    for i := uint64(0); i < n && r.err == nil; i++ {
+        suffix := r.string()
+        replacement := r.string()
+        if r.bool() {
+            d.AddCheckedRule(suffix, replacement)
+        } else {
+            d.AddRule(suffix, replacement)
+        }
+    }
+    d.exceptions = r.set()
+    d.bases = r.set()
+    d.MinStemLength = int(r.varint())
+    return d
+}
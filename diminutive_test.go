@@ -0,0 +1,120 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestReduce checks if diminutives and augmentatives are reduced and
+// lexicalized words are kept.
+func TestReduce(t *testing.T) {
+    var cases = []struct {
+        word    string
+        reduced string
+    }{
+        {"casinha", "casa"},
+        {"casinhas", "casas"},
+        {"livrinho", "livro"},
+        {"cafezinho", "cafe"},
+        {"mulherzinhas", "mulheres"},
+        {"florzinhas", "flores"},
+        {"florezinhas", "flores"},
+        {"animalzinhos", "animais"},
+        {"cafezinhos", "cafes"},
+        {"carrão", "carro"},
+        {"casarão", "casa"},
+        {"casarões", "casas"},
+        {"pezão", "pe"},
+        {"ricaço", "rico"},
+        {"vizinho", "vizinho"},
+        {"vizinhos", "vizinhos"},
+        {"padrinho", "padrinho"},
+        {"cartão", "cartão"},
+        {"cartões", "cartões"},
+        {"coração", "coração"},
+        {"falarão", "falarão"},
+        {"estarão", "estarão"},
+        {"ficarão", "ficarão"},
+        {"beberão", "beberão"},
+        {"darão", "darão"},
+        {"braço", "braço"},
+        {"praça", "praça"},
+        {"graça", "graça"},
+        {"traço", "traço"},
+        {"visita", "visita"},
+        {"escrito", "escrito"},
+        {"receita", "receita"},
+        {"camarão", "camarão"},
+        {"tubarão", "tubarão"},
+        {"razão", "razão"},
+        {"sobrinho", "sobrinho"},
+        {"João", "João"},
+        {"gatito", "gato"},
+        {"olhão", "olho"},
+        {"linha", "linha"},
+        {"vinho", "vinho"},
+        {"muitos", "muitos"},
+        {"crédito", "crédito"},
+        {"casa", "casa"},
+    }
+
+    d := NewDiminutiveReducer()
+    for _, c := range cases {
+        r := d.Reduce(c.word)
+        if r != c.reduced {
+            t.Errorf("Invalid reduction. word= %s expected= %s actual= %s\n",
+                c.word, c.reduced, r)
+        }
+    }
+}
+
+// TestDiminutiveStemmer checks if diminutives conflate with their base
+// word when the reducer is enabled in the Porter stemmer.
+func TestDiminutiveStemmer(t *testing.T) {
+    ps := NewPorterStemmer()
+    if ps.Stem("casinha") == ps.Stem("casa") {
+        t.Errorf("Reduction should be disabled by default\n")
+    }
+
+    ps.SetDiminutiveReducer(NewDiminutiveReducer())
+    stem := ps.Stem("casa")
+    for _, w := range []string{"casinha", "casarão", "casinhas"} {
+        if r := ps.Stem(w); r != stem {
+            t.Errorf("Invalid stem. word= %s expected= %s actual= %s\n",
+                w, stem, r)
+        }
+    }
+
+    if ps.Stem("falarão") != ps.Stem("falar") {
+        t.Errorf("Verb future should keep the verb stem: falarão\n")
+    }
+
+    if ps.Stem("vizinho") == ps.Stem("vizo") {
+        t.Errorf("Exception was reduced: vizinho\n")
+    }
+}
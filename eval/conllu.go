@@ -0,0 +1,201 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+    "text/tabwriter"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Word is a syntactic word of a CoNLL-U sentence. Fields hold the
+// columns of the file as written, with '_' for unspecified values.
+type Word struct {
+    ID     string // Word index, starting at 1 in each sentence
+    Form   string // Word form or punctuation symbol
+    Lemma  string // Lemma or stem of the word form
+    UPOS   string // Universal part of speech tag
+    XPOS   string // Language specific part of speech tag
+    Feats  string // Morphological features
+    Head   string // Head of the current word
+    DepRel string // Universal dependency relation to the head
+    Deps   string // Enhanced dependency graph
+    Misc   string // Any other annotation
+}
+
+// Sentence is a CoNLL-U sentence. Multiword token ranges and empty
+// nodes are not included in Words.
+type Sentence struct {
+    Comments []string // Comment lines, without the leading '#'
+    Words    []Word   // Syntactic words of the sentence
+}
+
+// CoNLLUReader reads sentences from a CoNLL-U file one at a time, so
+// large treebanks can be processed without loading them in memory.
+type CoNLLUReader struct {
+    sc   *bufio.Scanner // Scanner over the file lines
+    line int            // Number of the last line read
+}
+
+// Create a reader of CoNLL-U sentences.
+func NewCoNLLUReader(r io.Reader) *CoNLLUReader {
+    cr := new(CoNLLUReader)
+    cr.sc = bufio.NewScanner(r)
+    cr.sc.Buffer(make([]byte, 64*1024), 1024*1024)
+    return cr
+}
+
+// Read returns the next sentence. At the end of the input, nil and
+// io.EOF are returned.
+func (cr *CoNLLUReader) Read() (*Sentence, error) {
+    var s *Sentence
+    for cr.sc.Scan() {
+        cr.line++
+        l := strings.TrimRight(cr.sc.Text(), "\r")
+
+        if l == "" {
+            if s != nil {
+                return s, nil
+            }
+            continue
+        }
+
+        if s == nil {
+            s = new(Sentence)
+        }
+        if strings.HasPrefix(l, "#") {
+            s.Comments = append(s.Comments, strings.TrimSpace(l[1:]))
+            continue
+        }
+
+        fields := strings.Split(l, "\t")
+        if len(fields) != 10 {
+            return nil, fmt.Errorf("eval: line %d: expected 10 fields, found %d",
+                cr.line, len(fields))
+        }
+
+        // Skip multiword token ranges and empty nodes.
+        if strings.ContainsAny(fields[0], "-.") {
+            continue
+        }
+
+        s.Words = append(s.Words, Word{
+            ID: fields[0], Form: fields[1], Lemma: fields[2],
+            UPOS: fields[3], XPOS: fields[4], Feats: fields[5],
+            Head: fields[6], DepRel: fields[7], Deps: fields[8],
+            Misc: fields[9],
+        })
+    }
+
+    if err := cr.sc.Err(); err != nil {
+        return nil, err
+    }
+    if s != nil {
+        return s, nil
+    }
+    return nil, io.EOF
+}
+
+// ReadCoNLLUGold reads every sentence and builds gold groups of
+// lowercased forms sharing a lemma, for each universal part of speech.
+// The key "ALL" holds the groups of all words. Punctuation, symbols,
+// numbers and words without lemma are skipped.
+func ReadCoNLLUGold(cr *CoNLLUReader) (map[string]*Gold, error) {
+    golds := map[string]*Gold{"ALL": NewGold()}
+    for {
+        s, err := cr.Read()
+        if err == io.EOF {
+            return golds, nil
+        } else if err != nil {
+            return nil, err
+        }
+
+        for _, w := range s.Words {
+            if w.Lemma == "_" || w.UPOS == "PUNCT" || w.UPOS == "SYM" ||
+                w.UPOS == "NUM" || w.UPOS == "X" {
+                continue
+            }
+
+            g, ok := golds[w.UPOS]
+            if !ok {
+                g = NewGold()
+                golds[w.UPOS] = g
+            }
+            form := strings.ToLower(w.Form)
+            lemma := strings.ToLower(w.Lemma)
+            g.Add(form, lemma)
+            golds["ALL"].Add(form, w.UPOS+" "+lemma)
+        }
+    }
+}
+
+// LoadLemmatizer adds the form, lemma and universal part of speech of
+// every word to a lemmatizer, so that treebanks can be used as override
+// dictionaries.
+func LoadLemmatizer(l *ptstemmer.Lemmatizer, cr *CoNLLUReader) error {
+    for {
+        s, err := cr.Read()
+        if err == io.EOF {
+            return nil
+        } else if err != nil {
+            return err
+        }
+
+        for _, w := range s.Words {
+            if w.Lemma != "_" {
+                l.Add(w.Form, w.Lemma, w.UPOS)
+            }
+        }
+    }
+}
+
+// WritePOSReport evaluates each stemmer over the gold groups of each
+// part of speech and writes how often forms of the same lemma were
+// conflated (1-UI) and forms of different lemmas were kept apart (1-OI).
+func WritePOSReport(w io.Writer, golds map[string]*Gold, stemmers []NamedStemmer) error {
+    tags := make([]string, 0, len(golds))
+    for t := range golds {
+        tags = append(tags, t)
+    }
+    sort.Strings(tags)
+
+    tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintf(tw, "stemmer\tpos\twords\tstems\tconflated\tseparated\tUI\tOI\t\n")
+    for _, s := range stemmers {
+        for _, t := range tags {
+            r := Evaluate(s.Stemmer, golds[t])
+            fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.4f\t%.4f\t%.4f\t%.4f\t\n",
+                s.Name, t, r.Words, r.Stems, 1-r.UI, 1-r.OI, r.UI, r.OI)
+        }
+    }
+    return tw.Flush()
+}
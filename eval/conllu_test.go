@@ -0,0 +1,146 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+    "bytes"
+    "io"
+    "os"
+    "strings"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// TestCoNLLUReader checks if sentences are read and if multiword tokens
+// and empty nodes are skipped.
+func TestCoNLLUReader(t *testing.T) {
+    ip, err := os.Open("testdata/sample.conllu")
+    if err != nil {
+        t.Fatalf("Could not open test file: testdata/sample.conllu")
+    }
+    defer ip.Close()
+
+    cr := NewCoNLLUReader(ip)
+    var cases = []struct {
+        comments int
+        forms    string
+    }{
+        {2, "As casas ajudaram no ."},
+        {1, "A casa ajudou casando"},
+    }
+
+    for _, c := range cases {
+        s, err := cr.Read()
+        if err != nil {
+            t.Fatalf("Could not read sentence: %v\n", err)
+        }
+        forms := make([]string, 0)
+        for _, w := range s.Words {
+            forms = append(forms, w.Form)
+        }
+        if len(s.Comments) != c.comments || strings.Join(forms, " ") != c.forms {
+            t.Errorf("Wrong sentence. expected= %s actual= %v\n", c.forms, forms)
+        }
+    }
+
+    if _, err := cr.Read(); err != io.EOF {
+        t.Errorf("Expected end of file. err= %v\n", err)
+    }
+
+    cr = NewCoNLLUReader(strings.NewReader("1\tcasa\tcasa\tNOUN\n"))
+    if _, err := cr.Read(); err == nil || err == io.EOF {
+        t.Errorf("Invalid line accepted\n")
+    }
+}
+
+// TestCoNLLUGold checks the per part of speech evaluation of the Porter
+// stemmer over the sample treebank.
+func TestCoNLLUGold(t *testing.T) {
+    ip, err := os.Open("testdata/sample.conllu")
+    if err != nil {
+        t.Fatalf("Could not open test file: testdata/sample.conllu")
+    }
+    defer ip.Close()
+
+    golds, err := ReadCoNLLUGold(NewCoNLLUReader(ip))
+    if err != nil {
+        t.Fatalf("Could not read gold: %v\n", err)
+    }
+    if _, ok := golds["PUNCT"]; ok {
+        t.Errorf("Punctuation should be skipped\n")
+    }
+
+    ps := ptstemmer.NewPorterStemmer()
+    verbs := Evaluate(ps, golds["VERB"])
+    if verbs.Words != 3 || verbs.UI != 0 || verbs.OI != 0 {
+        t.Errorf("Wrong verb metrics: %+v\n", verbs)
+    }
+
+    // casando (casar) is conflated with casa (casa) when all words are
+    // considered.
+    all := Evaluate(ps, golds["ALL"])
+    if all.Words != 8 || all.OI == 0 {
+        t.Errorf("Wrong metrics: %+v\n", all)
+    }
+
+    var buf bytes.Buffer
+    err = WritePOSReport(&buf, golds, []NamedStemmer{{"porter", ps}})
+    if err != nil || strings.Count(buf.String(), "\n") != len(golds)+1 {
+        t.Errorf("Wrong report: %q\n", buf.String())
+    }
+}
+
+// TestLoadLemmatizer checks if treebank lemmas override stems.
+func TestLoadLemmatizer(t *testing.T) {
+    ip, err := os.Open("testdata/sample.conllu")
+    if err != nil {
+        t.Fatalf("Could not open test file: testdata/sample.conllu")
+    }
+    defer ip.Close()
+
+    l := ptstemmer.NewLemmatizer(nil)
+    if err := LoadLemmatizer(l, NewCoNLLUReader(ip)); err != nil {
+        t.Fatalf("Could not load lemmatizer: %v\n", err)
+    }
+
+    var cases = []struct {
+        word  string
+        lemma string
+    }{
+        {"casas", "casa"},
+        {"ajudaram", "ajudar"},
+        {"casando", "casar"},
+        {"ajudando", "ajud"},
+    }
+    for _, c := range cases {
+        if r := l.Stem(c.word); r != c.lemma {
+            t.Errorf("Invalid lemma. word= %s expected= %s actual= %s\n",
+                c.word, c.lemma, r)
+        }
+    }
+}
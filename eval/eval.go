@@ -0,0 +1,228 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package eval measures the quality of stemmers against gold conflation
+// groups. It implements the understemming and overstemming indexes
+// proposed by Paice and the stemmer strength metrics used by Frakes.
+package eval
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strings"
+    "text/tabwriter"
+    "unicode/utf8"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Gold groups words that should be conflated by a stemmer, such as the
+// inflected forms of a lemma. Each word belongs to a single group.
+type Gold struct {
+    groups map[string]string // Group of each word
+}
+
+// Create an empty set of gold groups.
+func NewGold() *Gold {
+    g := new(Gold)
+    g.groups = make(map[string]string)
+    return g
+}
+
+// Add a word to a group. If the word is already in a group it is kept
+// there.
+func (g *Gold) Add(word, group string) {
+    if _, ok := g.groups[word]; !ok {
+        g.groups[word] = group
+    }
+}
+
+// ReadGold reads gold groups from a file with one word per line followed
+// by its group, usually its lemma:
+//
+//      [word] [group]
+//
+// Empty lines and lines starting with '#' are ignored.
+func ReadGold(r io.Reader) (*Gold, error) {
+    g := NewGold()
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        fields := strings.Fields(sc.Text())
+        if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+            continue
+        }
+        if len(fields) != 2 {
+            return nil, fmt.Errorf("eval: line %d: expected word and group", line)
+        }
+        g.Add(fields[0], fields[1])
+    }
+    if err := sc.Err(); err != nil {
+        return nil, err
+    }
+    return g, nil
+}
+
+// Len returns the number of words.
+func (g *Gold) Len() int {
+    return len(g.groups)
+}
+
+// Words returns the sorted words of all groups.
+func (g *Gold) Words() []string {
+    res := make([]string, 0, len(g.groups))
+    for w := range g.groups {
+        res = append(res, w)
+    }
+    sort.Strings(res)
+    return res
+}
+
+// Result holds the metrics of a stemmer over a set of gold groups.
+type Result struct {
+    Words int // Number of distinct words
+    Stems int // Number of distinct stems
+
+    // Paice's metrics. UI is the fraction of word pairs of the same group
+    // that were not conflated, OI the fraction of word pairs of different
+    // groups that were conflated and SW = OI/UI is the stemming weight.
+    UI float64
+    OI float64
+    SW float64
+
+    // Frakes' metrics. MeanClassSize is the mean number of words per
+    // stem, which is also the word/stem ratio. IndexCompression is
+    // (Words-Stems)/Words. WordsChanged is the fraction of words that
+    // differ from their stem and MeanCharsRemoved the mean number of
+    // characters removed from each word.
+    MeanClassSize    float64
+    IndexCompression float64
+    WordsChanged     float64
+    MeanCharsRemoved float64
+}
+
+// Evaluate stems every gold word and computes the metrics of the
+// stemmer.
+func Evaluate(s ptstemmer.Stemmer, g *Gold) Result {
+    var res Result
+    res.Words = len(g.groups)
+    if res.Words == 0 {
+        return res
+    }
+
+    // Count words by group, by stem and by (group, stem) pair.
+    groupSize := make(map[string]int)
+    stemSize := make(map[string]int)
+    pairSize := make(map[[2]string]int)
+    changed := 0
+    removed := 0
+    for w, grp := range g.groups {
+        stem := s.Stem(w)
+        groupSize[grp]++
+        stemSize[stem]++
+        pairSize[[2]string{grp, stem}]++
+        if stem != w {
+            changed++
+        }
+        removed += utf8.RuneCountInString(w) - utf8.RuneCountInString(stem)
+    }
+
+    total := float64(res.Words)
+    res.Stems = len(stemSize)
+    res.MeanClassSize = total / float64(res.Stems)
+    res.IndexCompression = (total - float64(res.Stems)) / total
+    res.WordsChanged = float64(changed) / total
+    res.MeanCharsRemoved = float64(removed) / total
+
+    // Desired merges and non-merges of each group.
+    var gdmt, gdnt float64
+    for _, n := range groupSize {
+        gdmt += 0.5 * float64(n) * float64(n-1)
+        gdnt += 0.5 * float64(n) * (total - float64(n))
+    }
+
+    // Unachieved merges: pairs of a group split among different stems.
+    // Wrong merges: pairs of a stem coming from different groups.
+    var gumt, gwmt float64
+    for key, n := range pairSize {
+        gumt += 0.5 * float64(n) * float64(groupSize[key[0]]-n)
+        gwmt += 0.5 * float64(n) * float64(stemSize[key[1]]-n)
+    }
+
+    if gdmt > 0 {
+        res.UI = gumt / gdmt
+    }
+    if gdnt > 0 {
+        res.OI = gwmt / gdnt
+    }
+    if res.UI > 0 {
+        res.SW = res.OI / res.UI
+    }
+    return res
+}
+
+// NamedStemmer is a stemmer along with the name shown in reports.
+type NamedStemmer struct {
+    Name    string            // Name shown in reports
+    Stemmer ptstemmer.Stemmer // Evaluated stemmer
+}
+
+// WriteReport evaluates each stemmer and writes a table comparing their
+// metrics.
+func WriteReport(w io.Writer, g *Gold, stemmers []NamedStemmer) error {
+    tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintf(tw, "stemmer\twords\tstems\tUI\tOI\tSW\tMCS\tICF\tchanged\tremoved\t\n")
+    for _, s := range stemmers {
+        r := Evaluate(s.Stemmer, g)
+        fmt.Fprintf(tw, "%s\t%d\t%d\t%.4f\t%.4f\t%.4f\t%.2f\t%.4f\t%.4f\t%.2f\t\n",
+            s.Name, r.Words, r.Stems, r.UI, r.OI, r.SW, r.MeanClassSize,
+            r.IndexCompression, r.WordsChanged, r.MeanCharsRemoved)
+    }
+    return tw.Flush()
+}
+
+// Identity is a baseline stemmer that returns words unchanged.
+type Identity struct{}
+
+// Stem returns the word itself.
+func (Identity) Stem(word string) string {
+    return word
+}
+
+// Truncate is a baseline stemmer that keeps the first N runes of words.
+type Truncate struct {
+    N int // Number of runes kept
+}
+
+// Stem returns the first N runes of the word.
+func (t Truncate) Stem(word string) string {
+    runes := []rune(word)
+    if len(runes) <= t.N {
+        return word
+    }
+    return string(runes[:t.N])
+}
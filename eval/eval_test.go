@@ -0,0 +1,122 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+    "bytes"
+    "math"
+    "strings"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// mapStemmer returns the stems stored in a map.
+type mapStemmer map[string]string
+
+// Stem returns the stored stem of the word.
+func (m mapStemmer) Stem(word string) string {
+    return m[word]
+}
+
+// Return true if two metrics are equal up to rounding.
+func near(a, b float64) bool {
+    return math.Abs(a-b) < 1e-9
+}
+
+// TestEvaluate checks the metrics of a stemmer with known errors. Group
+// A is split in two stems and its words are conflated with group B.
+func TestEvaluate(t *testing.T) {
+    g, err := ReadGold(strings.NewReader("# word group\n" +
+        "a1 A\na2 A\na3 A\nb1 B\nb2 B\n"))
+    if err != nil {
+        t.Fatalf("Could not read gold: %v\n", err)
+    }
+
+    s := mapStemmer{"a1": "x", "a2": "x", "a3": "y", "b1": "x", "b2": "x"}
+    r := Evaluate(s, g)
+
+    var cases = []struct {
+        name     string
+        expected float64
+        actual   float64
+    }{
+        {"UI", 0.5, r.UI},
+        {"OI", 4.0 / 6.0, r.OI},
+        {"SW", 4.0 / 3.0, r.SW},
+        {"MCS", 2.5, r.MeanClassSize},
+        {"ICF", 0.6, r.IndexCompression},
+        {"changed", 1, r.WordsChanged},
+        {"removed", 1, r.MeanCharsRemoved},
+    }
+
+    for _, c := range cases {
+        if !near(c.expected, c.actual) {
+            t.Errorf("Wrong metric. name= %s expected= %f actual= %f\n",
+                c.name, c.expected, c.actual)
+        }
+    }
+    if r.Words != 5 || r.Stems != 2 {
+        t.Errorf("Wrong counts. words= %d stems= %d\n", r.Words, r.Stems)
+    }
+}
+
+// TestBaselines checks the metrics of the baseline stemmers, which never
+// understem or never overstem.
+func TestBaselines(t *testing.T) {
+    g := NewGold()
+    for _, w := range []string{"ajudar", "ajudou", "ajuda"} {
+        g.Add(w, "ajudar")
+    }
+    for _, w := range []string{"ajuizar", "ajuizou"} {
+        g.Add(w, "ajuizar")
+    }
+
+    r := Evaluate(Identity{}, g)
+    if r.UI != 1 || r.OI != 0 || r.IndexCompression != 0 {
+        t.Errorf("Wrong identity metrics: %+v\n", r)
+    }
+
+    r = Evaluate(Truncate{2}, g)
+    if r.UI != 0 || r.OI != 1 || r.Stems != 1 {
+        t.Errorf("Wrong truncate metrics: %+v\n", r)
+    }
+
+    r = Evaluate(ptstemmer.NewPorterStemmer(), g)
+    if r.UI != 0 || r.OI != 0 {
+        t.Errorf("Wrong porter metrics: %+v\n", r)
+    }
+
+    var buf bytes.Buffer
+    err := WriteReport(&buf, g, []NamedStemmer{
+        {"none", Identity{}},
+        {"porter", ptstemmer.NewPorterStemmer()},
+    })
+    if err != nil || strings.Count(buf.String(), "\n") != 3 {
+        t.Errorf("Wrong report: %q\n", buf.String())
+    }
+}
@@ -0,0 +1,315 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "math"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "text/tabwriter"
+
+    "github.com/tncardoso/ptstemmer"
+    "github.com/tncardoso/ptstemmer/index"
+)
+
+// Document is a document of a test collection.
+type Document struct {
+    ID   string // Document number, as used in qrels
+    Text string // Document contents
+}
+
+// Topic is an information need of a test collection.
+type Topic struct {
+    ID          string // Topic number, as used in qrels
+    Title       string // Short query
+    Description string // Longer description of the need
+}
+
+// Qrels holds relevance judgements: the relevance grade of documents for
+// each topic. Documents not listed are considered not relevant.
+type Qrels map[string]map[string]int
+
+// Matches SGML tags.
+var tagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// Return the blocks of text between the given opening and closing tags.
+func blocks(text, open, close string) []string {
+    res := make([]string, 0)
+    for {
+        i := strings.Index(text, open)
+        if i < 0 {
+            return res
+        }
+        text = text[i+len(open):]
+
+        j := strings.Index(text, close)
+        if j < 0 {
+            return append(res, text)
+        }
+        res = append(res, text[:j])
+        text = text[j+len(close):]
+    }
+}
+
+// Return the text following a tag up to the next tag, without the given
+// label, or "" if the tag is not found.
+func field(text, tag, label string) string {
+    i := strings.Index(text, tag)
+    if i < 0 {
+        return ""
+    }
+    text = text[i+len(tag):]
+    if j := strings.Index(text, "<"); j >= 0 {
+        text = text[:j]
+    }
+    text = strings.TrimSpace(text)
+    return strings.TrimSpace(strings.TrimPrefix(text, label))
+}
+
+// ReadTRECDocuments reads documents in the TREC SGML format. Each
+// document is enclosed in <DOC> tags and identified by its <DOCNO>. The
+// remaining contents, without tags, are the document text.
+func ReadTRECDocuments(r io.Reader) ([]Document, error) {
+    data, err := io.ReadAll(r)
+    if err != nil {
+        return nil, err
+    }
+
+    docs := make([]Document, 0)
+    for _, b := range blocks(string(data), "<DOC>", "</DOC>") {
+        id := field(b, "<DOCNO>", "")
+        if id == "" {
+            return nil, fmt.Errorf("eval: document %d without DOCNO", len(docs)+1)
+        }
+        text := b
+        if i := strings.Index(b, "</DOCNO>"); i >= 0 {
+            text = b[i+len("</DOCNO>"):]
+        }
+        text = strings.TrimSpace(tagRegexp.ReplaceAllString(text, " "))
+        docs = append(docs, Document{ID: id, Text: text})
+    }
+    return docs, nil
+}
+
+// ReadTRECTopics reads topics in the TREC format. Each topic is enclosed
+// in <top> tags and has <num>, <title> and <desc> fields. Closing field
+// tags are optional.
+func ReadTRECTopics(r io.Reader) ([]Topic, error) {
+    data, err := io.ReadAll(r)
+    if err != nil {
+        return nil, err
+    }
+
+    topics := make([]Topic, 0)
+    for _, b := range blocks(string(data), "<top>", "</top>") {
+        t := Topic{
+            ID:          field(b, "<num>", "Number:"),
+            Title:       field(b, "<title>", "Topic:"),
+            Description: field(b, "<desc>", "Description:"),
+        }
+        if t.ID == "" {
+            return nil, fmt.Errorf("eval: topic %d without num", len(topics)+1)
+        }
+        topics = append(topics, t)
+    }
+    return topics, nil
+}
+
+// ReadQrels reads relevance judgements in the TREC format:
+//
+//      [topic] [iteration] [document] [relevance]
+func ReadQrels(r io.Reader) (Qrels, error) {
+    qrels := make(Qrels)
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        fields := strings.Fields(sc.Text())
+        if len(fields) == 0 {
+            continue
+        }
+        if len(fields) != 4 {
+            return nil, fmt.Errorf("eval: line %d: expected 4 qrels fields", line)
+        }
+        rel, err := strconv.Atoi(fields[3])
+        if err != nil {
+            return nil, fmt.Errorf("eval: line %d: invalid relevance %q", line, fields[3])
+        }
+
+        if _, ok := qrels[fields[0]]; !ok {
+            qrels[fields[0]] = make(map[string]int)
+        }
+        qrels[fields[0]][fields[2]] = rel
+    }
+    if err := sc.Err(); err != nil {
+        return nil, err
+    }
+    return qrels, nil
+}
+
+// AveragePrecision returns the mean of the precision at the rank of each
+// relevant document, over all relevant documents of the judgements.
+func AveragePrecision(ranking []string, rels map[string]int) float64 {
+    total := 0
+    for _, r := range rels {
+        if r > 0 {
+            total++
+        }
+    }
+    if total == 0 {
+        return 0
+    }
+
+    found := 0
+    sum := 0.0
+    for i, id := range ranking {
+        if rels[id] > 0 {
+            found++
+            sum += float64(found) / float64(i+1)
+        }
+    }
+    return sum / float64(total)
+}
+
+// PrecisionAt returns the fraction of relevant documents among the first
+// k of the ranking.
+func PrecisionAt(k int, ranking []string, rels map[string]int) float64 {
+    found := 0
+    for i := 0; i < k && i < len(ranking); i++ {
+        if rels[ranking[i]] > 0 {
+            found++
+        }
+    }
+    return float64(found) / float64(k)
+}
+
+// NDCGAt returns the normalized discounted cumulative gain of the first
+// k documents of the ranking, using 2^rel - 1 as gain.
+func NDCGAt(k int, ranking []string, rels map[string]int) float64 {
+    dcg := 0.0
+    for i := 0; i < k && i < len(ranking); i++ {
+        if r := rels[ranking[i]]; r > 0 {
+            dcg += (math.Pow(2, float64(r)) - 1) / math.Log2(float64(i+2))
+        }
+    }
+
+    grades := make([]int, 0, len(rels))
+    for _, r := range rels {
+        if r > 0 {
+            grades = append(grades, r)
+        }
+    }
+    sort.Sort(sort.Reverse(sort.IntSlice(grades)))
+
+    idcg := 0.0
+    for i := 0; i < k && i < len(grades); i++ {
+        idcg += (math.Pow(2, float64(grades[i])) - 1) / math.Log2(float64(i+2))
+    }
+    if idcg == 0 {
+        return 0
+    }
+    return dcg / idcg
+}
+
+// NamedAnalyzer is an analyzer along with the name shown in reports.
+type NamedAnalyzer struct {
+    Name     string              // Name shown in reports
+    Analyzer *ptstemmer.Analyzer // Analyzer of documents and queries
+}
+
+// IRResult holds the retrieval effectiveness of an analyzer, averaged
+// over the topics with relevant documents.
+type IRResult struct {
+    Name   string  // Name of the analyzer
+    Topics int     // Number of evaluated topics
+    MAP    float64 // Mean average precision
+    P10    float64 // Mean precision at 10
+    NDCG10 float64 // Mean nDCG at 10
+}
+
+// Return true if the judgements have at least one relevant document.
+func hasRelevant(rels map[string]int) bool {
+    for _, r := range rels {
+        if r > 0 {
+            return true
+        }
+    }
+    return false
+}
+
+// Benchmark indexes the documents with each analyzer, ranks them with
+// BM25 for the title of each topic and evaluates the rankings against
+// the judgements. Topics without relevant documents are skipped. The first 1000 documents of each ranking are used.
+func Benchmark(docs []Document, topics []Topic, qrels Qrels, analyzers []NamedAnalyzer) []IRResult {
+    res := make([]IRResult, 0, len(analyzers))
+    for _, a := range analyzers {
+        idx := index.New(a.Analyzer)
+        for _, d := range docs {
+            idx.Add(d.ID, d.Text)
+        }
+
+        r := IRResult{Name: a.Name}
+        for _, t := range topics {
+            rels := qrels[t.ID]
+            if !hasRelevant(rels) {
+                continue
+            }
+
+            hits := idx.Search(index.Any(t.Title), 1000)
+            ranking := make([]string, len(hits))
+            for i, h := range hits {
+                ranking[i] = h.ID
+            }
+            r.MAP += AveragePrecision(ranking, rels)
+            r.P10 += PrecisionAt(10, ranking, rels)
+            r.NDCG10 += NDCGAt(10, ranking, rels)
+            r.Topics++
+        }
+
+        if r.Topics > 0 {
+            r.MAP /= float64(r.Topics)
+            r.P10 /= float64(r.Topics)
+            r.NDCG10 /= float64(r.Topics)
+        }
+        res = append(res, r)
+    }
+    return res
+}
+
+// WriteIRReport writes a table comparing benchmark results.
+func WriteIRReport(w io.Writer, results []IRResult) error {
+    tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+    fmt.Fprintf(tw, "analyzer\ttopics\tMAP\tP@10\tnDCG@10\t\n")
+    for _, r := range results {
+        fmt.Fprintf(tw, "%s\t%d\t%.4f\t%.4f\t%.4f\t\n",
+            r.Name, r.Topics, r.MAP, r.P10, r.NDCG10)
+    }
+    return tw.Flush()
+}
@@ -0,0 +1,119 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+    "bytes"
+    "os"
+    "strings"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// TestRankingMetrics checks MAP, P@10 and nDCG@10 against values
+// computed by hand.
+func TestRankingMetrics(t *testing.T) {
+    rels := map[string]int{"a": 2, "b": 1, "c": 0, "d": 1}
+    ranking := []string{"a", "c", "b", "e"}
+
+    var cases = []struct {
+        name     string
+        expected float64
+        actual   float64
+    }{
+        {"AP", (1.0 + 2.0/3.0) / 3.0, AveragePrecision(ranking, rels)},
+        {"P@10", 0.2, PrecisionAt(10, ranking, rels)},
+        {"P@2", 0.5, PrecisionAt(2, ranking, rels)},
+        {"nDCG@10", (3 + 1/2.0) / (3 + 1/1.5849625007211563 + 1/2.0),
+            NDCGAt(10, ranking, rels)},
+        {"AP empty", 0, AveragePrecision(ranking, map[string]int{"c": 0})},
+    }
+
+    for _, c := range cases {
+        if !near(c.expected, c.actual) {
+            t.Errorf("Wrong metric. name= %s expected= %f actual= %f\n",
+                c.name, c.expected, c.actual)
+        }
+    }
+}
+
+// Open a test file or stop the test.
+func open(t *testing.T, path string) *os.File {
+    ip, err := os.Open(path)
+    if err != nil {
+        t.Fatalf("Could not open test file: %s", path)
+    }
+    return ip
+}
+
+// TestBenchmark checks if the sample collection is read and if stemming
+// improves its retrieval effectiveness.
+func TestBenchmark(t *testing.T) {
+    ip := open(t, "testdata/docs.trec")
+    defer ip.Close()
+    docs, err := ReadTRECDocuments(ip)
+    if err != nil || len(docs) != 4 || docs[1].Text != "A ajuda chegou para as famílias." {
+        t.Fatalf("Could not read documents: %v %v\n", docs, err)
+    }
+
+    ip = open(t, "testdata/topics.trec")
+    defer ip.Close()
+    topics, err := ReadTRECTopics(ip)
+    if err != nil || len(topics) != 3 {
+        t.Fatalf("Could not read topics: %v %v\n", topics, err)
+    }
+    if topics[0] != (Topic{"1", "ajudar crianças", "Documentos sobre ajuda a crianças."}) ||
+        topics[1] != (Topic{"2", "governo", "Medidas do governo."}) {
+        t.Errorf("Wrong topics: %v\n", topics)
+    }
+
+    ip = open(t, "testdata/qrels.txt")
+    defer ip.Close()
+    qrels, err := ReadQrels(ip)
+    if err != nil || len(qrels) != 2 || qrels["1"]["D1"] != 2 {
+        t.Fatalf("Could not read qrels: %v %v\n", qrels, err)
+    }
+    qrels["3"] = map[string]int{"D1": 0, "D2": 0}
+
+    results := Benchmark(docs, topics, qrels, []NamedAnalyzer{
+        {"none", ptstemmer.NewAnalyzer(nil)},
+        {"porter", ptstemmer.NewAnalyzer(ptstemmer.NewPorterStemmer())},
+    })
+    none, porter := results[0], results[1]
+    if none.Topics != 2 || porter.Topics != 2 {
+        t.Errorf("Wrong number of topics: %v\n", results)
+    }
+    if !near(none.MAP, 0.5) || !near(porter.MAP, 1) || porter.NDCG10 <= none.NDCG10 {
+        t.Errorf("Wrong results: %v\n", results)
+    }
+
+    var buf bytes.Buffer
+    if err := WriteIRReport(&buf, results); err != nil || strings.Count(buf.String(), "\n") != 3 {
+        t.Errorf("Wrong report: %q\n", buf.String())
+    }
+}
@@ -0,0 +1,197 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "sort"
+    "strings"
+    "unicode/utf8"
+)
+
+// Span is a region of a text given by byte offsets.
+type Span struct {
+    Start int // Offset of the first byte
+    End   int // Offset after the last byte
+}
+
+// Snippet is a fragment of a text with its matches marked up.
+type Snippet struct {
+    Text  string  // Fragment with markup around matches
+    Start int     // Offset of the fragment in the original text
+    End   int     // Offset after the fragment in the original text
+    Score float64 // Relevance of the fragment to the query
+}
+
+// Highlighter marks the words of a text that match a query. Words match
+// when they are analyzed to the same terms as a query word, so
+// "ajudaram" is highlighted for the query "ajuda". Text is not escaped,
+// callers producing HTML should escape it first.
+type Highlighter struct {
+    analyzer     *Analyzer // Analyzer of texts and queries
+    FragmentSize int       // Maximum fragment length in runes
+    MaxFragments int       // Maximum number of snippets, all if <= 0
+    PreTag       string    // Markup inserted before a match
+    PostTag      string    // Markup inserted after a match
+}
+
+// Create a highlighter using the standard analyzer with the given
+// stemmer.
+func NewHighlighter(stemmer Stemmer) *Highlighter {
+    h := new(Highlighter)
+    h.analyzer = NewAnalyzer(stemmer)
+    h.FragmentSize = 100
+    h.MaxFragments = 3
+    h.PreTag = "<em>"
+    h.PostTag = "</em>"
+    return h
+}
+
+// Find the matching tokens of a text. Returns the matched tokens, in
+// text order and without repeated offsets.
+func (h *Highlighter) matches(text, query string) []Token {
+    terms := make(map[string]bool)
+    for _, t := range h.analyzer.Terms(query) {
+        terms[t] = true
+    }
+
+    res := make([]Token, 0)
+    for _, t := range h.analyzer.Analyze(text) {
+        if !terms[t.Text] {
+            continue
+        }
+        if n := len(res); n > 0 && res[n-1].Start == t.Start {
+            continue
+        }
+        res = append(res, t)
+    }
+    return res
+}
+
+// Spans returns the regions of the text matching the query. Hits
+// separated only by white space are merged in a single span.
+func (h *Highlighter) Spans(text, query string) []Span {
+    res := make([]Span, 0)
+    for _, t := range h.matches(text, query) {
+        n := len(res)
+        if n > 0 && strings.TrimSpace(text[res[n-1].End:t.Start]) == "" {
+            res[n-1].End = t.End
+            continue
+        }
+        res = append(res, Span{t.Start, t.End})
+    }
+    return res
+}
+
+// Insert the markup around the spans in [start, end). Spans crossing
+// the boundaries are clipped to the region.
+func (h *Highlighter) markup(text string, spans []Span, start, end int) string {
+    var b strings.Builder
+    pos := start
+    for _, s := range spans {
+        if s.Start < start {
+            s.Start = start
+        }
+        if s.End > end {
+            s.End = end
+        }
+        if s.Start >= s.End {
+            continue
+        }
+        b.WriteString(text[pos:s.Start])
+        b.WriteString(h.PreTag)
+        b.WriteString(text[s.Start:s.End])
+        b.WriteString(h.PostTag)
+        pos = s.End
+    }
+    b.WriteString(text[pos:end])
+    return b.String()
+}
+
+// Highlight returns the whole text with markup around the spans matching
+// the query.
+func (h *Highlighter) Highlight(text, query string) string {
+    return h.markup(text, h.Spans(text, query), 0, len(text))
+}
+
+// Split the text in fragments of at most FragmentSize runes, cutting at
+// word boundaries. Words longer than a fragment have their own fragment.
+func (h *Highlighter) fragments(text string) []Span {
+    res := make([]Span, 0)
+    words := Tokenize(text)
+    for i := 0; i < len(words); {
+        start := words[i].Start
+        end := words[i].End
+        i++
+        for i < len(words) &&
+            utf8.RuneCountInString(text[start:words[i].End]) <= h.FragmentSize {
+            end = words[i].End
+            i++
+        }
+        res = append(res, Span{start, end})
+    }
+    return res
+}
+
+// Snippets returns the fragments of the text that best match the query,
+// with matches marked up. Fragments are scored by the number of distinct
+// query terms they contain, with repeated hits as a tie breaker, and at
+// most MaxFragments are returned in decreasing score order. If
+// MaxFragments is not positive, every matching fragment is returned.
+func (h *Highlighter) Snippets(text, query string) []Snippet {
+    hits := h.matches(text, query)
+    spans := h.Spans(text, query)
+
+    res := make([]Snippet, 0)
+    for _, f := range h.fragments(text) {
+        distinct := make(map[string]bool)
+        count := 0
+        for _, t := range hits {
+            if t.Start >= f.Start && t.End <= f.End {
+                distinct[t.Text] = true
+                count++
+            }
+        }
+        if count == 0 {
+            continue
+        }
+
+        res = append(res, Snippet{
+            Text:  h.markup(text, spans, f.Start, f.End),
+            Start: f.Start,
+            End:   f.End,
+            Score: float64(len(distinct)) + 0.1*float64(count-len(distinct)),
+        })
+    }
+
+    sort.SliceStable(res, func(i, j int) bool {
+        return res[i].Score > res[j].Score
+    })
+    if h.MaxFragments > 0 && len(res) > h.MaxFragments {
+        res = res[:h.MaxFragments]
+    }
+    return res
+}
@@ -0,0 +1,125 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "testing"
+)
+
+// TestHighlight checks if inflected forms of the query words are marked
+// and if adjacent hits are merged.
+func TestHighlight(t *testing.T) {
+    var cases = []struct {
+        text   string
+        query  string
+        marked string
+    }{
+        {"Eles ajudaram muito.", "ajuda", "Eles <em>ajudaram</em> muito."},
+        {"O Banco Central decidiu.", "bancos central",
+            "O <em>Banco Central</em> decidiu."},
+        {"Banco, central.", "banco central", "<em>Banco</em>, <em>central</em>."},
+        {"A casa do povo.", "de", "A casa do povo."},
+        {"Gosto daquela casa.", "aquelas casas", "Gosto daquela <em>casa</em>."},
+    }
+
+    h := NewHighlighter(NewPorterStemmer())
+    for _, c := range cases {
+        r := h.Highlight(c.text, c.query)
+        if r != c.marked {
+            t.Errorf("Invalid highlight. text= %s expected= %s actual= %s\n",
+                c.text, c.marked, r)
+        }
+    }
+}
+
+// TestSnippets checks if fragments are cut at word boundaries and ranked
+// by the number of query terms they contain.
+func TestSnippets(t *testing.T) {
+    text := "O governo anunciou medidas. Os juros caíram ontem. " +
+        "O governo ajudou as famílias com juros menores."
+
+    h := NewHighlighter(NewPorterStemmer())
+    h.FragmentSize = 30
+    h.PreTag, h.PostTag = "[", "]"
+
+    snippets := h.Snippets(text, "governo juros")
+    if len(snippets) != 3 {
+        t.Fatalf("Wrong number of snippets: %v\n", snippets)
+    }
+
+    expected := []string{
+        "[juros] caíram ontem. O [governo]",
+        "O [governo] anunciou medidas. Os",
+        "ajudou as famílias com [juros]",
+    }
+    for i, s := range snippets {
+        if s.Text != expected[i] {
+            t.Errorf("Wrong snippet. expected= %s actual= %s\n", expected[i], s.Text)
+        }
+        if len([]rune(text[s.Start:s.End])) > h.FragmentSize {
+            t.Errorf("Snippet too long: %s\n", text[s.Start:s.End])
+        }
+    }
+
+    h.MaxFragments = 1
+    if len(h.Snippets(text, "governo juros")) != 1 {
+        t.Errorf("Too many snippets\n")
+    }
+    if len(h.Snippets(text, "banco")) != 0 {
+        t.Errorf("Snippets without matches\n")
+    }
+
+    h.MaxFragments = -1
+    if len(h.Snippets(text, "governo juros")) != 3 {
+        t.Errorf("Non positive MaxFragments should return all snippets\n")
+    }
+}
+
+// TestSnippetsClipSpans checks if spans crossing a fragment boundary are
+// marked in every fragment they overlap.
+func TestSnippetsClipSpans(t *testing.T) {
+    text := "aaaa bbbb ajuda ajudaram cccc dddd eeee"
+
+    h := NewHighlighter(NewPorterStemmer())
+    h.FragmentSize = 12
+    h.PreTag, h.PostTag = "[", "]"
+
+    if spans := h.Spans(text, "ajuda"); len(spans) != 1 {
+        t.Fatalf("Spans should be merged: %v\n", spans)
+    }
+
+    snippets := h.Snippets(text, "ajuda")
+    res := make([]string, len(snippets))
+    for i, s := range snippets {
+        res[i] = s.Text
+    }
+    expected := []string{"[ajuda]", "[ajudaram]"}
+    if !reflect.DeepEqual(res, expected) {
+        t.Errorf("Wrong snippets. expected= %v actual= %v\n", expected, res)
+    }
+}
@@ -0,0 +1,281 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "regexp"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// DefaultNeutralForms maps gender neutral forms that can not be
+// recognised by their ending to their masculine and feminine forms.
+var DefaultNeutralForms = map[string][]string{
+    "elu":      {"ele", "ela"},
+    "elus":     {"eles", "elas"},
+    "elx":      {"ele", "ela"},
+    "elxs":     {"eles", "elas"},
+    "el@":      {"ele", "ela"},
+    "el@s":     {"eles", "elas"},
+    "delu":     {"dele", "dela"},
+    "delus":    {"deles", "delas"},
+    "delx":     {"dele", "dela"},
+    "delxs":    {"deles", "delas"},
+    "nelu":     {"nele", "nela"},
+    "nelus":    {"neles", "nelas"},
+    "aquelu":   {"aquele", "aquela"},
+    "aquelus":  {"aqueles", "aquelas"},
+    "ume":      {"um", "uma"},
+    "umes":     {"uns", "umas"},
+    "tode":     {"todo", "toda"},
+    "todes":    {"todos", "todas"},
+    "amigue":   {"amigo", "amiga"},
+    "amigues":  {"amigos", "amigas"},
+    "menine":   {"menino", "menina"},
+    "menines":  {"meninos", "meninas"},
+    "alune":    {"aluno", "aluna"},
+    "alunes":   {"alunos", "alunas"},
+    "queride":  {"querido", "querida"},
+    "querides": {"queridos", "queridas"},
+    "obrigade": {"obrigado", "obrigada"},
+    "moces":    {"moços", "moças"},
+    "filhe":    {"filho", "filha"},
+    "filhes":   {"filhos", "filhas"},
+}
+
+// Patterns of inclusive endings: parenthetical gender endings, as in
+// "alunos(as)", and neutral markers replacing the gender vowel, as in
+// "amig@s" and "todxs".
+var (
+    inclusiveParenPattern  = regexp.MustCompile(`^(\pL+)\((a|as|o|os)\)$`)
+    inclusiveMarkerPattern = regexp.MustCompile(`^(\pL+?)([@x])(s?)$`)
+)
+
+// InclusiveFilter replaces gender neutral and inclusive forms, such as
+// "todes", "amigxs", "amig@s" and "alunos(as)", by their masculine form,
+// or by both masculine and feminine forms, so that they can be stemmed.
+type InclusiveFilter struct {
+    table map[string][]string // Masculine and feminine forms of each form
+    Both  bool                // Emit the feminine form after the masculine
+}
+
+// Create a filter using a copy of the given table of neutral forms. If
+// table is nil, DefaultNeutralForms is used.
+func NewInclusiveFilter(table map[string][]string) *InclusiveFilter {
+    if table == nil {
+        table = DefaultNeutralForms
+    }
+
+    f := new(InclusiveFilter)
+    f.table = make(map[string][]string, len(table))
+    for k, v := range table {
+        f.table[k] = v
+    }
+    return f
+}
+
+// Add a neutral form to the filter table, replacing any previous entry.
+// Returns the filter to allow chained calls.
+func (f *InclusiveFilter) Add(form, masculine, feminine string) *InclusiveFilter {
+    f.table[strings.ToLower(form)] = []string{masculine, feminine}
+    return f
+}
+
+// Remove a neutral form from the filter table.
+func (f *InclusiveFilter) Remove(form string) {
+    delete(f.table, strings.ToLower(form))
+}
+
+// Replace the ending of word, if present, returning the word and true.
+func replaceEnding(word, ending, replacement string) (string, bool) {
+    if strings.HasSuffix(word, ending) {
+        return word[:len(word)-len(ending)] + replacement, true
+    }
+    return word, false
+}
+
+// Return the masculine and feminine forms of a word with a parenthetical
+// gender ending.
+func parenForms(base, ending string) (string, string) {
+    plural := strings.HasSuffix(ending, "s")
+    if ending[0] == 'a' {
+        if plural {
+            for _, e := range []string{"os", "es"} {
+                if fem, ok := replaceEnding(base, e, "as"); ok {
+                    return base, fem
+                }
+            }
+        } else if fem, ok := replaceEnding(base, "o", "a"); ok {
+            return base, fem
+        }
+        return base, base + ending
+    }
+
+    if masc, ok := replaceEnding(base, "a"+ending[1:], ending); ok {
+        return masc, base
+    }
+    return base + ending, base
+}
+
+// Return true if the letter closes a syllable, so that a masculine form
+// does not end in o, as in "professor".
+func isClosingConsonant(r byte) bool {
+    return strings.IndexByte("lrsz", r) >= 0
+}
+
+// Forms returns the masculine and feminine forms of an inclusive form. If
+// the word is not recognised, nil is returned. Lookup is case
+// insensitive and forms are returned in lower case.
+func (f *InclusiveFilter) Forms(word string) []string {
+    word = strings.ToLower(word)
+    if forms, ok := f.table[word]; ok {
+        return forms
+    }
+
+    if m := inclusiveParenPattern.FindStringSubmatch(word); m != nil {
+        masc, fem := parenForms(m[1], m[2])
+        return []string{masc, fem}
+    }
+
+    m := inclusiveMarkerPattern.FindStringSubmatch(word)
+    if m == nil {
+        return nil
+    }
+    stem, marker, plural := m[1], m[2], m[3]
+    last := stem[len(stem)-1]
+    if len(stem) < 2 || marker == "x" && plural == "" && strings.IndexByte("aeiou", last) >= 0 {
+        return nil
+    }
+
+    if isClosingConsonant(last) {
+        masc := stem
+        if plural != "" {
+            masc += "es"
+        }
+        return []string{masc, stem + "a" + plural}
+    }
+    return []string{stem + "o" + plural, stem + "a" + plural}
+}
+
+// Filter replaces every recognised form in the token stream. Emitted
+// forms keep the offsets of the original word.
+func (f *InclusiveFilter) Filter(tokens []Token) []Token {
+    res := make([]Token, 0, len(tokens))
+    for _, t := range tokens {
+        var forms []string
+        if t.Type == TokenWord {
+            forms = f.Forms(t.Text)
+        }
+        if forms == nil {
+            res = append(res, t)
+            continue
+        }
+
+        if !f.Both {
+            forms = forms[:1]
+        }
+        for _, w := range forms {
+            res = append(res, Token{Text: w, Start: t.Start, End: t.End})
+        }
+    }
+    return res
+}
+
+// Gender endings of inclusive writing, such as "amig@s" and
+// "aluno(a)", kept as part of the preceding word. Longer endings come
+// first.
+var inclusiveEndings = []string{"(as)", "(os)", "(a)", "(o)", "@s", "@"}
+
+// Return the length of the inclusive ending at the beginning of rest, or
+// 0 if there is none. The ending must follow a letter and must not be
+// followed by a word rune.
+func inclusiveEndingLen(word, rest string) int {
+    last, _ := utf8.DecodeLastRuneInString(word)
+    if !unicode.IsLetter(last) {
+        return 0
+    }
+    for _, e := range inclusiveEndings {
+        if !strings.HasPrefix(rest, e) {
+            continue
+        }
+        next, _ := utf8.DecodeRuneInString(rest[len(e):])
+        if len(rest) == len(e) || !isWordRune(next) {
+            return len(e)
+        }
+    }
+    return 0
+}
+
+// TokenizeInclusive splits a text in words like Tokenize, but keeps
+// gender endings of inclusive writing, such as "@s" and "(as)", as part
+// of the preceding word so that InclusiveFilter can recognise them.
+func TokenizeInclusive(text string) []Token {
+    tokens := make([]Token, 0)
+    start := -1
+    skip := 0
+
+    for i, r := range text {
+        if i < skip {
+            continue
+        }
+        if isWordRune(r) {
+            if start < 0 {
+                start = i
+            }
+        } else if start >= 0 {
+            end := i + inclusiveEndingLen(text[start:i], text[i:])
+            tokens = append(tokens, Token{Text: text[start:end], Start: start, End: end})
+            start = -1
+            skip = end
+        }
+    }
+
+    if start >= 0 {
+        tokens = append(tokens, Token{Text: text[start:], Start: start, End: len(text)})
+    }
+
+    return tokens
+}
+
+// Create an analyzer for texts with inclusive writing: it works like
+// NewAnalyzer, but keeps inclusive endings in their words and replaces
+// inclusive forms by their masculine form before stop words are removed.
+func NewInclusiveAnalyzer(stemmer Stemmer) *Analyzer {
+    a := NewAnalyzer(stemmer)
+    a.Tokenizer = TokenizeInclusive
+    a.Filters = []TokenFilter{
+        LowercaseFilter{},
+        NewContractionFilter(nil),
+        NewInclusiveFilter(nil),
+        NewStopwordFilter(nil),
+    }
+    if stemmer != nil {
+        a.Filters = append(a.Filters, StemFilter{stemmer})
+    }
+    return a
+}
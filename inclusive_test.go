@@ -0,0 +1,122 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "testing"
+)
+
+// TestTokenizeInclusive checks if inclusive endings stay in their word
+// and if Tokenize keeps splitting them.
+func TestTokenizeInclusive(t *testing.T) {
+    text := "Olá amig@s, alunos(as) e professor(a)! a@b.com (a) e@"
+    expected := []string{"Olá", "amig@s", "alunos(as)", "e", "professor(a)", "a", "b", "com", "a", "e@"}
+
+    tokens := TokenizeInclusive(text)
+    res := make([]string, len(tokens))
+    for i, tk := range tokens {
+        res[i] = tk.Text
+        if text[tk.Start:tk.End] != tk.Text {
+            t.Errorf("Offsets do not match text. token= %v\n", tk)
+        }
+    }
+    if !reflect.DeepEqual(res, expected) {
+        t.Errorf("Wrong tokens. expected= %v actual= %v\n", expected, res)
+    }
+
+    expected = []string{"Olá", "amig", "s", "alunos", "as", "e", "professor", "a", "a", "b", "com", "a", "e"}
+    tokens = Tokenize(text)
+    res = make([]string, len(tokens))
+    for i, tk := range tokens {
+        res[i] = tk.Text
+    }
+    if !reflect.DeepEqual(res, expected) {
+        t.Errorf("Wrong plain tokens. expected= %v actual= %v\n", expected, res)
+    }
+}
+
+// TestInclusiveForms checks the masculine and feminine forms of
+// inclusive words.
+func TestInclusiveForms(t *testing.T) {
+    f := NewInclusiveFilter(nil)
+    tests := []struct {
+        word     string
+        expected []string
+    }{
+        {"todes", []string{"todos", "todas"}},
+        {"Elu", []string{"ele", "ela"}},
+        {"amigxs", []string{"amigos", "amigas"}},
+        {"amig@s", []string{"amigos", "amigas"}},
+        {"menin@", []string{"menino", "menina"}},
+        {"todx", []string{"todo", "toda"}},
+        {"professorxs", []string{"professores", "professoras"}},
+        {"alunos(as)", []string{"alunos", "alunas"}},
+        {"aluno(a)", []string{"aluno", "aluna"}},
+        {"professor(a)", []string{"professor", "professora"}},
+        {"professores(as)", []string{"professores", "professoras"}},
+        {"alunas(os)", []string{"alunos", "alunas"}},
+        {"tórax", nil},
+        {"xerox", nil},
+        {"linux", nil},
+        {"fontes", nil},
+        {"casa", nil},
+    }
+
+    for _, test := range tests {
+        if forms := f.Forms(test.word); !reflect.DeepEqual(forms, test.expected) {
+            t.Errorf("Wrong forms of %s. expected= %v actual= %v\n", test.word, test.expected, forms)
+        }
+    }
+
+    f.Add("garote", "garoto", "garota").Remove("todes")
+    if f.Forms("garote") == nil || f.Forms("todes") != nil {
+        t.Errorf("Wrong filter table\n")
+    }
+}
+
+// TestInclusiveFilter checks emitted tokens and stems.
+func TestInclusiveFilter(t *testing.T) {
+    f := NewInclusiveFilter(nil)
+    f.Both = true
+    tokens := f.Filter(TokenizeInclusive("Querides alunos(as)"))
+    expected := []Token{
+        {"queridos", 0, 8, TokenWord},
+        {"queridas", 0, 8, TokenWord},
+        {"alunos", 9, 19, TokenWord},
+        {"alunas", 9, 19, TokenWord},
+    }
+    if !reflect.DeepEqual(tokens, expected) {
+        t.Errorf("Wrong tokens. expected= %v actual= %v\n", expected, tokens)
+    }
+
+    a := NewInclusiveAnalyzer(NewPorterStemmer())
+    terms := a.Terms("Amigxs, amig@s e amigues")
+    if !reflect.DeepEqual(terms, []string{"amig", "amig", "amig"}) {
+        t.Errorf("Wrong terms: %v\n", terms)
+    }
+}
@@ -0,0 +1,245 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package index implements a small in-memory inverted index for
+// portuguese text. Documents are analyzed with a ptstemmer.Analyzer, so
+// queries match every inflection of a word, and can be retrieved with
+// boolean, phrase and BM25 ranked queries.
+package index
+
+import (
+    "encoding/gob"
+    "io"
+    "math"
+    "sort"
+
+    "github.com/tncardoso/ptstemmer"
+    "github.com/tncardoso/ptstemmer/internal/counting"
+)
+
+// BM25 parameters.
+const (
+    bm25K1 = 1.2
+    bm25B  = 0.75
+)
+
+// Hit is a document matching a query along with its score.
+type Hit struct {
+    ID    string  // Document identifier
+    Score float64 // BM25 score of the document
+}
+
+// document holds the analyzed terms of an indexed document and the
+// position of each term, counted in words of the original text.
+type document struct {
+    Terms     []string // Analyzed terms, in text order
+    Positions []int    // Word position of each term
+}
+
+// Index is an inverted index over analyzed documents. It is not safe for
+// concurrent use.
+type Index struct {
+    analyzer *ptstemmer.Analyzer         // Analyzer of documents and queries
+    docs     map[string]*document        // Indexed documents
+    postings map[string]map[string][]int // Positions of each term in each document
+    totalLen int                         // Number of terms of all documents
+}
+
+// Create an empty index. If analyzer is nil, the standard analyzer with
+// a PorterStemmer is used.
+func New(analyzer *ptstemmer.Analyzer) *Index {
+    if analyzer == nil {
+        analyzer = ptstemmer.NewAnalyzer(ptstemmer.NewPorterStemmer())
+    }
+
+    idx := new(Index)
+    idx.analyzer = analyzer
+    idx.docs = make(map[string]*document)
+    idx.postings = make(map[string]map[string][]int)
+    return idx
+}
+
+// Split a text in words with the tokenizer of the analyzer.
+func (idx *Index) tokenize(text string) []ptstemmer.Token {
+    if idx.analyzer.Tokenizer != nil {
+        return idx.analyzer.Tokenizer(text)
+    }
+    return ptstemmer.Tokenize(text)
+}
+
+// Analyze a text, returning its terms and their word positions. The
+// position of a term is the index of the word it came from among the
+// words found by the analyzer tokenizer, so removed stop words still
+// count as gaps.
+func (idx *Index) analyze(text string) *document {
+    words := idx.tokenize(text)
+    ordinal := make(map[int]int, len(words))
+    for i, w := range words {
+        ordinal[w.Start] = i
+    }
+
+    d := new(document)
+    for _, t := range idx.analyzer.Analyze(text) {
+        d.Terms = append(d.Terms, t.Text)
+        d.Positions = append(d.Positions, ordinal[t.Start])
+    }
+    return d
+}
+
+// Add a document to the index, replacing any document with the same
+// identifier.
+func (idx *Index) Add(id, text string) {
+    idx.Delete(id)
+    idx.insert(id, idx.analyze(text))
+}
+
+// Add the postings of an analyzed document.
+func (idx *Index) insert(id string, d *document) {
+    idx.docs[id] = d
+    idx.totalLen += len(d.Terms)
+    for i, t := range d.Terms {
+        p, ok := idx.postings[t]
+        if !ok {
+            p = make(map[string][]int)
+            idx.postings[t] = p
+        }
+        p[id] = append(p[id], d.Positions[i])
+    }
+}
+
+// Delete a document from the index. Returns true if the document was
+// indexed.
+func (idx *Index) Delete(id string) bool {
+    d, ok := idx.docs[id]
+    if !ok {
+        return false
+    }
+
+    for _, t := range d.Terms {
+        delete(idx.postings[t], id)
+        if len(idx.postings[t]) == 0 {
+            delete(idx.postings, t)
+        }
+    }
+    idx.totalLen -= len(d.Terms)
+    delete(idx.docs, id)
+    return true
+}
+
+// Len returns the number of indexed documents.
+func (idx *Index) Len() int {
+    return len(idx.docs)
+}
+
+// Match returns the sorted identifiers of the documents matching the
+// query.
+func (idx *Index) Match(q Query) []string {
+    set, ok := q.match(idx)
+    if !ok {
+        return []string{}
+    }
+
+    res := make([]string, 0, len(set))
+    for id := range set {
+        res = append(res, id)
+    }
+    sort.Strings(res)
+    return res
+}
+
+// Search returns the k best documents matching the query, ranked by the
+// BM25 score of the terms the query requires or allows. If k is not
+// positive, every matching document is returned. Use Any to rank every
+// document containing at least one word of a text.
+func (idx *Index) Search(q Query, k int) []Hit {
+    set, ok := q.match(idx)
+    if !ok {
+        return []Hit{}
+    }
+
+    terms := make(map[string]bool)
+    q.terms(idx, terms)
+
+    n := float64(len(idx.docs))
+    avg := 1.0
+    if idx.totalLen > 0 {
+        avg = float64(idx.totalLen) / n
+    }
+    hits := make([]Hit, 0, len(set))
+    for id := range set {
+        d := idx.docs[id]
+        score := 0.0
+        for t := range terms {
+            tf := float64(len(idx.postings[t][id]))
+            if tf == 0 {
+                continue
+            }
+            df := float64(len(idx.postings[t]))
+            idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+            norm := 1 - bm25B + bm25B*float64(len(d.Terms))/avg
+            score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+        }
+        hits = append(hits, Hit{ID: id, Score: score})
+    }
+
+    sort.Slice(hits, func(i, j int) bool {
+        if hits[i].Score != hits[j].Score {
+            return hits[i].Score > hits[j].Score
+        }
+        return hits[i].ID < hits[j].ID
+    })
+    if k > 0 && len(hits) > k {
+        hits = hits[:k]
+    }
+    return hits
+}
+
+// WriteTo implements the io.WriterTo interface, writing a snapshot of
+// the indexed documents. The analyzer is not included in the snapshot.
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+    cw := &counting.Writer{W: w}
+    err := gob.NewEncoder(cw).Encode(idx.docs)
+    return cw.N, err
+}
+
+// ReadFrom implements the io.ReaderFrom interface, replacing the indexed
+// documents by a snapshot written by WriteTo. The snapshot should be
+// read by an index using the analyzer that wrote it.
+func (idx *Index) ReadFrom(r io.Reader) (int64, error) {
+    cr := &counting.Reader{R: r}
+    docs := make(map[string]*document)
+    if err := gob.NewDecoder(cr).Decode(&docs); err != nil {
+        return cr.N, err
+    }
+
+    idx.docs = make(map[string]*document)
+    idx.postings = make(map[string]map[string][]int)
+    idx.totalLen = 0
+    for id, d := range docs {
+        idx.insert(id, d)
+    }
+    return cr.N, nil
+}
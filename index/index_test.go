@@ -0,0 +1,145 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package index
+
+import (
+    "bytes"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Create an index with a few sample documents.
+func sampleIndex() *Index {
+    idx := New(nil)
+    idx.Add("d1", "Os voluntários ajudaram a criança perdida.")
+    idx.Add("d2", "A ajuda chegou para as famílias da cidade.")
+    idx.Add("d3", "Crianças brincam no parque da cidade.")
+    idx.Add("d4", "O banco central subiu os juros.")
+    idx.Add("d5", "Sentou no banco da praça central.")
+    return idx
+}
+
+// TestSearch checks if documents are ranked by BM25 and if any
+// inflection of the query words matches.
+func TestSearch(t *testing.T) {
+    idx := sampleIndex()
+
+    hits := idx.Search(Any("ajudar crianças"), 10)
+    if len(hits) != 3 || hits[0].ID != "d1" {
+        t.Fatalf("Wrong hits: %v\n", hits)
+    }
+    if hits[0].Score <= hits[1].Score {
+        t.Errorf("Hits are not ranked: %v\n", hits)
+    }
+
+    if hits := idx.Search(Any("ajudar crianças"), 1); len(hits) != 1 {
+        t.Errorf("Wrong number of hits. expected= 1 actual= %d\n", len(hits))
+    }
+    for _, k := range []int{0, -1} {
+        if hits := idx.Search(Any("ajudar crianças"), k); len(hits) != 3 {
+            t.Errorf("Non positive k should return all hits. k= %d hits= %v\n", k, hits)
+        }
+    }
+    if hits := idx.Search(Any("de"), 10); len(hits) != 0 {
+        t.Errorf("Stop words should not match: %v\n", hits)
+    }
+}
+
+// TestDelete checks if deleted and replaced documents stop matching.
+func TestDelete(t *testing.T) {
+    idx := sampleIndex()
+
+    if !idx.Delete("d1") || idx.Delete("d1") || idx.Len() != 4 {
+        t.Errorf("Wrong deletion. len= %d\n", idx.Len())
+    }
+    if ids := idx.Match(Term("ajudaram")); len(ids) != 1 || ids[0] != "d2" {
+        t.Errorf("Deleted document matches: %v\n", ids)
+    }
+
+    idx.Add("d2", "Nada a ver.")
+    if ids := idx.Match(Term("ajuda")); len(ids) != 0 {
+        t.Errorf("Replaced document matches: %v\n", ids)
+    }
+    if _, ok := idx.postings["ajud"]; ok {
+        t.Errorf("Empty postings were kept\n")
+    }
+}
+
+// TestSnapshot checks if an index read from a snapshot answers queries
+// as the original.
+func TestSnapshot(t *testing.T) {
+    idx := sampleIndex()
+
+    var buf bytes.Buffer
+    n, err := idx.WriteTo(&buf)
+    if err != nil || n != int64(buf.Len()) {
+        t.Fatalf("Could not write snapshot. n= %d err= %v\n", n, err)
+    }
+
+    loaded := New(nil)
+    loaded.Add("other", "outro documento")
+    if _, err := loaded.ReadFrom(&buf); err != nil {
+        t.Fatalf("Could not read snapshot: %v\n", err)
+    }
+    if loaded.Len() != idx.Len() || loaded.totalLen != idx.totalLen {
+        t.Errorf("Wrong snapshot size. len= %d\n", loaded.Len())
+    }
+
+    for _, q := range []string{"ajudar crianças", "banco central", "cidade"} {
+        h1 := idx.Search(Any(q), 10)
+        h2 := loaded.Search(Any(q), 10)
+        if len(h1) != len(h2) {
+            t.Errorf("Wrong hits. query= %s expected= %v actual= %v\n", q, h1, h2)
+            continue
+        }
+        for i := range h1 {
+            if h1[i] != h2[i] {
+                t.Errorf("Wrong hit. query= %s expected= %v actual= %v\n",
+                    q, h1[i], h2[i])
+            }
+        }
+    }
+}
+
+// TestSocialPositions checks if positions follow the analyzer tokenizer,
+// so phrases next to hashtags and mentions match.
+func TestSocialPositions(t *testing.T) {
+    idx := New(ptstemmer.NewSocialAnalyzer(ptstemmer.NewPorterStemmer()))
+    idx.Add("d1", "@ana #fica dica boa")
+    idx.Add("d2", "fica a dica")
+
+    if ids := idx.Match(Phrase("#fica dica")); len(ids) != 1 || ids[0] != "d1" {
+        t.Errorf("Wrong hashtag phrase match: %v\n", ids)
+    }
+    if ids := idx.Match(Phrase("@ana #fica")); len(ids) != 1 || ids[0] != "d1" {
+        t.Errorf("Wrong mention phrase match: %v\n", ids)
+    }
+    if ids := idx.Match(Phrase("fica dica")); len(ids) != 1 || ids[0] != "d1" {
+        t.Errorf("Wrong phrase match: %v\n", ids)
+    }
+}
@@ -0,0 +1,306 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package index
+
+import (
+    "sort"
+    "strings"
+)
+
+// Query selects documents of an index. Queries are built with Term,
+// Phrase, Any, All, And, Or, Not and Parse. Words in queries are analyzed
+// with the analyzer of the index they are run against.
+type Query interface {
+    // Return the matching documents. The second result is false when the
+    // query has no terms left after analysis, e.g. a single stop word,
+    // and should be ignored by the enclosing query.
+    match(idx *Index) (map[string]bool, bool)
+
+    // Add the analyzed terms used to rank the matching documents.
+    terms(idx *Index, set map[string]bool)
+}
+
+// phraseQuery matches documents containing the terms of a text in the
+// same relative positions.
+type phraseQuery struct {
+    text string // Text of the phrase
+}
+
+// Phrase matches documents containing the words of the text in order.
+// Stop words are not required to match but keep their place, so
+// "casa do povo" does not match "casa povo".
+func Phrase(text string) Query {
+    return phraseQuery{text}
+}
+
+// Term matches documents containing any form of the word that shares its
+// analyzed terms.
+func Term(word string) Query {
+    return phraseQuery{word}
+}
+
+// Return true if the sorted positions contain p.
+func hasPosition(positions []int, p int) bool {
+    i := sort.SearchInts(positions, p)
+    return i < len(positions) && positions[i] == p
+}
+
+// Return the documents containing the phrase terms in order.
+func (q phraseQuery) match(idx *Index) (map[string]bool, bool) {
+    d := idx.analyze(q.text)
+    if len(d.Terms) == 0 {
+        return nil, false
+    }
+
+    res := make(map[string]bool)
+    for id, first := range idx.postings[d.Terms[0]] {
+        for _, p := range first {
+            found := true
+            for i := 1; i < len(d.Terms) && found; i++ {
+                pos := p + d.Positions[i] - d.Positions[0]
+                found = hasPosition(idx.postings[d.Terms[i]][id], pos)
+            }
+            if found {
+                res[id] = true
+                break
+            }
+        }
+    }
+    return res, true
+}
+
+// Add the terms of the phrase.
+func (q phraseQuery) terms(idx *Index, set map[string]bool) {
+    for _, t := range idx.analyzer.Terms(q.text) {
+        set[t] = true
+    }
+}
+
+// andQuery matches documents matching all subqueries.
+type andQuery struct {
+    queries []Query // Subqueries
+}
+
+// And matches documents matching every query.
+func And(queries ...Query) Query {
+    return andQuery{queries}
+}
+
+// Return the intersection of the subquery results.
+func (q andQuery) match(idx *Index) (map[string]bool, bool) {
+    var res map[string]bool
+    for _, sub := range q.queries {
+        set, ok := sub.match(idx)
+        if !ok {
+            continue
+        }
+        if res == nil {
+            res = set
+            continue
+        }
+        for id := range res {
+            if !set[id] {
+                delete(res, id)
+            }
+        }
+    }
+    return res, res != nil
+}
+
+// Add the terms of every subquery.
+func (q andQuery) terms(idx *Index, set map[string]bool) {
+    for _, sub := range q.queries {
+        sub.terms(idx, set)
+    }
+}
+
+// orQuery matches documents matching any subquery.
+type orQuery struct {
+    queries []Query // Subqueries
+}
+
+// Or matches documents matching at least one query.
+func Or(queries ...Query) Query {
+    return orQuery{queries}
+}
+
+// Return the union of the subquery results.
+func (q orQuery) match(idx *Index) (map[string]bool, bool) {
+    var res map[string]bool
+    for _, sub := range q.queries {
+        set, ok := sub.match(idx)
+        if !ok {
+            continue
+        }
+        if res == nil {
+            res = make(map[string]bool)
+        }
+        for id := range set {
+            res[id] = true
+        }
+    }
+    return res, res != nil
+}
+
+// Add the terms of every subquery.
+func (q orQuery) terms(idx *Index, set map[string]bool) {
+    for _, sub := range q.queries {
+        sub.terms(idx, set)
+    }
+}
+
+// notQuery matches documents not matching a subquery.
+type notQuery struct {
+    query Query // Excluded query
+}
+
+// Not matches documents that do not match the query. Inside And, it
+// excludes documents from the other results.
+func Not(query Query) Query {
+    return notQuery{query}
+}
+
+// Return the documents not matched by the subquery.
+func (q notQuery) match(idx *Index) (map[string]bool, bool) {
+    set, ok := q.query.match(idx)
+    if !ok {
+        return nil, false
+    }
+
+    res := make(map[string]bool)
+    for id := range idx.docs {
+        if !set[id] {
+            res[id] = true
+        }
+    }
+    return res, true
+}
+
+// Excluded terms are not used for ranking.
+func (q notQuery) terms(idx *Index, set map[string]bool) {
+}
+
+// wordsQuery matches documents containing the words of a text, split
+// by the tokenizer of the index it is run against.
+type wordsQuery struct {
+    text string // Text of the words
+    all  bool   // Whether every word is required
+}
+
+// Any matches documents containing at least one word of the text. It is
+// the usual query for ranked retrieval.
+func Any(text string) Query {
+    return wordsQuery{text, false}
+}
+
+// All matches documents containing every word of the text, in any
+// order.
+func All(text string) Query {
+    return wordsQuery{text, true}
+}
+
+// Build the query of the words of the text as split by the index.
+func (q wordsQuery) query(idx *Index) Query {
+    words := idx.tokenize(q.text)
+    queries := make([]Query, len(words))
+    for i, w := range words {
+        queries[i] = Term(w.Text)
+    }
+    if q.all {
+        return And(queries...)
+    }
+    return Or(queries...)
+}
+
+// Return the documents matching the words.
+func (q wordsQuery) match(idx *Index) (map[string]bool, bool) {
+    return q.query(idx).match(idx)
+}
+
+// Add the terms of the words.
+func (q wordsQuery) terms(idx *Index, set map[string]bool) {
+    q.query(idx).terms(idx, set)
+}
+
+// Parse builds a query from a simple syntax. Words and quoted phrases
+// are required, a leading '-' excludes a word or phrase and the keyword
+// OR separates alternatives:
+//
+//      "banco central" juros -inflação OR selic
+//
+// matches documents with the phrase "banco central" and the word "juros"
+// but not "inflação", or documents with "selic".
+func Parse(query string) Query {
+    clauses := [][]Query{{}}
+    for {
+        query = strings.TrimLeft(query, " \t\n")
+        if query == "" {
+            break
+        }
+
+        neg := strings.HasPrefix(query, "-")
+        if neg {
+            query = query[1:]
+        }
+
+        var q Query
+        if strings.HasPrefix(query, "\"") {
+            text, rest, _ := strings.Cut(query[1:], "\"")
+            q, query = Phrase(text), rest
+        } else {
+            word := query
+            if i := strings.IndexAny(query, " \t\n"); i >= 0 {
+                word, query = query[:i], query[i:]
+            } else {
+                query = ""
+            }
+            if word == "OR" && !neg {
+                clauses = append(clauses, []Query{})
+                continue
+            }
+            q = Term(word)
+        }
+
+        if neg {
+            q = Not(q)
+        }
+        clauses[len(clauses)-1] = append(clauses[len(clauses)-1], q)
+    }
+
+    alternatives := make([]Query, len(clauses))
+    for i, c := range clauses {
+        if len(c) == 1 {
+            alternatives[i] = c[0]
+        } else {
+            alternatives[i] = And(c...)
+        }
+    }
+    if len(alternatives) == 1 {
+        return alternatives[0]
+    }
+    return Or(alternatives...)
+}
@@ -0,0 +1,93 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package index
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// TestQueries checks boolean, phrase and parsed queries.
+func TestQueries(t *testing.T) {
+    idx := sampleIndex()
+
+    var cases = []struct {
+        name  string
+        query Query
+        ids   string
+    }{
+        {"term", Term("cidades"), "d2 d3"},
+        {"and", And(Term("cidade"), Term("criança")), "d3"},
+        {"or", Or(Term("juros"), Term("praça")), "d4 d5"},
+        {"not", And(Term("cidade"), Not(Term("parque"))), "d2"},
+        {"all", All("banco central"), "d4 d5"},
+        {"phrase", Phrase("banco central"), "d4"},
+        {"phrase gap", Phrase("banco praça"), ""},
+        {"phrase stop", Phrase("banco da praça"), "d5"},
+        {"stop word", And(Term("de"), Term("juros")), "d4"},
+        {"parse", Parse(`"banco central" OR cidade -parque`), "d2 d4"},
+        {"parse neg", Parse(`banco -"banco central"`), "d5"},
+        {"parse empty", Parse(``), ""},
+    }
+
+    for _, c := range cases {
+        ids := strings.Join(idx.Match(c.query), " ")
+        if ids != c.ids {
+            t.Errorf("Wrong match. query= %s expected= %s actual= %s\n",
+                c.name, c.ids, ids)
+        }
+    }
+
+    hits := idx.Search(Parse(`banco -juros`), 10)
+    if len(hits) != 1 || hits[0].ID != "d5" || hits[0].Score <= 0 {
+        t.Errorf("Wrong hits: %v\n", hits)
+    }
+}
+
+// TestWordsQueries checks if Any and All split words with the tokenizer
+// of the index analyzer.
+func TestWordsQueries(t *testing.T) {
+    idx := New(ptstemmer.NewInclusiveAnalyzer(ptstemmer.NewPorterStemmer()))
+    idx.Add("d1", "Bom dia, amig@s!")
+    idx.Add("d2", "Os amigos chegaram cedo.")
+    idx.Add("d3", "O s de sol.")
+    if ids := idx.Match(All("amig@s")); len(ids) != 2 {
+        t.Errorf("Wrong inclusive match: %v\n", ids)
+    }
+
+    idx = New(ptstemmer.NewSocialAnalyzer(ptstemmer.NewPorterStemmer()))
+    idx.Add("d1", "Vale a pena #FicaDica")
+    idx.Add("d2", "Ficadica sem sentido")
+    if ids := idx.Match(All("#FicaDica")); len(ids) != 1 || ids[0] != "d1" {
+        t.Errorf("Wrong hashtag match: %v\n", ids)
+    }
+    if hits := idx.Search(Any("#FicaDica"), 0); len(hits) != 1 || hits[0].ID != "d1" {
+        t.Errorf("Wrong hashtag hits: %v\n", hits)
+    }
+}
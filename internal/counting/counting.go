@@ -0,0 +1,61 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package counting provides readers and writers that count the bytes
+// going through them. It is used to implement io.ReaderFrom and
+// io.WriterTo on top of decoders and encoders that do not report how
+// much they read or wrote.
+package counting
+
+import (
+    "io"
+)
+
+// Reader counts the bytes read from the underlying reader.
+type Reader struct {
+    R io.Reader // Underlying reader
+    N int64     // Bytes read so far
+}
+
+// Read implements the io.Reader interface.
+func (cr *Reader) Read(p []byte) (int, error) {
+    n, err := cr.R.Read(p)
+    cr.N += int64(n)
+    return n, err
+}
+
+// Writer counts the bytes written to the underlying writer.
+type Writer struct {
+    W io.Writer // Underlying writer
+    N int64     // Bytes written so far
+}
+
+// Write implements the io.Writer interface.
+func (cw *Writer) Write(p []byte) (int, error) {
+    n, err := cw.W.Write(p)
+    cw.N += int64(n)
+    return n, err
+}
@@ -0,0 +1,276 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package keyword extracts keywords and keyphrases from portuguese text.
+// Words are compared by stem, so inflections of a word count as the same
+// keyword, and each keyword is reported with its most frequent surface
+// form. Any ptstemmer.Stemmer can be used.
+package keyword
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Keyword is a word or phrase extracted from a text.
+type Keyword struct {
+    Stem  string  // Stems of the keyword words, separated by spaces
+    Text  string  // Most frequent surface form of the keyword
+    Score float64 // Relevance of the keyword, higher is better
+}
+
+// Background holds document frequencies of stems in a reference corpus,
+// used to weight the terms of a text by their rarity.
+type Background struct {
+    Docs    int            // Number of documents in the corpus
+    DocFreq map[string]int // Number of documents containing each stem
+}
+
+// Create an empty background.
+func NewBackground() *Background {
+    b := new(Background)
+    b.DocFreq = make(map[string]int)
+    return b
+}
+
+// Add a document given by its terms.
+func (b *Background) Add(terms []string) {
+    b.Docs++
+    seen := make(map[string]bool)
+    for _, t := range terms {
+        if !seen[t] {
+            seen[t] = true
+            b.DocFreq[t]++
+        }
+    }
+}
+
+// IDF returns the smoothed inverse document frequency of a stem.
+func (b *Background) IDF(stem string) float64 {
+    return math.Log(float64(b.Docs+1)/float64(b.DocFreq[stem]+1)) + 1
+}
+
+// WriteTo implements the io.WriterTo interface. The first line holds the
+// number of documents and each following line a stem and its document
+// frequency, separated by a tab.
+func (b *Background) WriteTo(w io.Writer) (int64, error) {
+    stems := make([]string, 0, len(b.DocFreq))
+    for s := range b.DocFreq {
+        stems = append(stems, s)
+    }
+    sort.Strings(stems)
+
+    bw := bufio.NewWriter(w)
+    n, err := fmt.Fprintf(bw, "%d\n", b.Docs)
+    total := int64(n)
+    for _, s := range stems {
+        if err != nil {
+            return total, err
+        }
+        n, err = fmt.Fprintf(bw, "%s\t%d\n", s, b.DocFreq[s])
+        total += int64(n)
+    }
+    if err != nil {
+        return total, err
+    }
+    return total, bw.Flush()
+}
+
+// ReadBackground reads a background written by WriteTo.
+func ReadBackground(r io.Reader) (*Background, error) {
+    b := NewBackground()
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        if line == 1 {
+            docs, err := strconv.Atoi(strings.TrimSpace(sc.Text()))
+            if err != nil {
+                return nil, fmt.Errorf("keyword: line 1: invalid document count")
+            }
+            b.Docs = docs
+            continue
+        }
+
+        stem, df, ok := strings.Cut(sc.Text(), "\t")
+        n, err := strconv.Atoi(df)
+        if !ok || err != nil {
+            return nil, fmt.Errorf("keyword: line %d: invalid frequency", line)
+        }
+        b.DocFreq[stem] = n
+    }
+    if err := sc.Err(); err != nil {
+        return nil, err
+    }
+    return b, nil
+}
+
+// phraseStemmer stems every word of a phrase with an analyzer, so that
+// an Unstemmer can find the display form of phrases.
+type phraseStemmer struct {
+    analyzer *ptstemmer.Analyzer // Analyzer of each phrase
+}
+
+// Stem returns the terms of the phrase separated by spaces.
+func (p phraseStemmer) Stem(phrase string) string {
+    return strings.Join(p.analyzer.Terms(phrase), " ")
+}
+
+// Extractor extracts keywords with TF-IDF and keyphrases with RAKE.
+type Extractor struct {
+    analyzer       *ptstemmer.Analyzer       // Analyzer with stop word removal and stemming
+    stopwords      *ptstemmer.StopwordFilter // Stop words delimiting phrases
+    Background     *Background               // Document frequencies used by TFIDF
+    MaxPhraseWords int                       // Maximum words of a RAKE phrase
+}
+
+// Create an extractor using the standard analyzer with the given stemmer
+// and an empty background.
+func NewExtractor(stemmer ptstemmer.Stemmer) *Extractor {
+    e := new(Extractor)
+    e.analyzer = ptstemmer.NewAnalyzer(stemmer)
+    e.stopwords = ptstemmer.NewStopwordFilter(nil)
+    e.Background = NewBackground()
+    e.MaxPhraseWords = 4
+    return e
+}
+
+// Learn adds a text of the reference corpus to the background.
+func (e *Extractor) Learn(text string) {
+    e.Background.Add(e.analyzer.Terms(text))
+}
+
+// Sort keywords by decreasing score, then by stem, and keep the first k.
+// If k is not positive, every keyword is kept.
+func best(keywords []Keyword, k int) []Keyword {
+    sort.Slice(keywords, func(i, j int) bool {
+        if keywords[i].Score != keywords[j].Score {
+            return keywords[i].Score > keywords[j].Score
+        }
+        return keywords[i].Stem < keywords[j].Stem
+    })
+    if k > 0 && len(keywords) > k {
+        keywords = keywords[:k]
+    }
+    return keywords
+}
+
+// TFIDF returns the k stems of the text with the highest term frequency
+// times inverse document frequency in the background. If k is not
+// positive, every stem is returned.
+func (e *Extractor) TFIDF(text string, k int) []Keyword {
+    tokens := e.analyzer.Analyze(text)
+    forms := ptstemmer.NewUnstemmer(phraseStemmer{e.analyzer})
+    tf := make(map[string]int)
+    for _, t := range tokens {
+        tf[t.Text]++
+        forms.Add(text[t.Start:t.End])
+    }
+
+    res := make([]Keyword, 0, len(tf))
+    for stem, n := range tf {
+        form, _ := forms.Unstem(stem)
+        score := float64(n) / float64(len(tokens)) * e.Background.IDF(stem)
+        res = append(res, Keyword{Stem: stem, Text: form, Score: score})
+    }
+    return best(res, k)
+}
+
+// Split a text in candidate phrases: sequences of words without stop
+// words or punctuation between them. Long sequences are split in phrases
+// of at most MaxPhraseWords words.
+func (e *Extractor) phrases(text string) [][]ptstemmer.Token {
+    res := make([][]ptstemmer.Token, 0)
+    cur := make([]ptstemmer.Token, 0)
+    flush := func() {
+        if len(cur) > 0 {
+            res = append(res, cur)
+            cur = make([]ptstemmer.Token, 0)
+        }
+    }
+
+    words := ptstemmer.Tokenize(text)
+    for i, w := range words {
+        if i > 0 && strings.TrimSpace(text[words[i-1].End:w.Start]) != "" {
+            flush()
+        }
+        if e.stopwords.IsStopword(w.Text) || len(e.analyzer.Terms(w.Text)) == 0 {
+            flush()
+            continue
+        }
+        if len(cur) == e.MaxPhraseWords {
+            flush()
+        }
+        cur = append(cur, w)
+    }
+    flush()
+    return res
+}
+
+// RAKE returns the k best keyphrases of the text, scored with the Rapid
+// Automatic Keyword Extraction method. Each word is scored by its degree,
+// the total length of the phrases it occurs in, divided by its
+// frequency, and each phrase by the sum of its word scores. If k is not
+// positive, every phrase is returned.
+func (e *Extractor) RAKE(text string, k int) []Keyword {
+    phrases := e.phrases(text)
+    forms := ptstemmer.NewUnstemmer(phraseStemmer{e.analyzer})
+
+    freq := make(map[string]int)
+    degree := make(map[string]int)
+    keys := make([][]string, len(phrases))
+    for i, p := range phrases {
+        keys[i] = e.analyzer.Terms(text[p[0].Start:p[len(p)-1].End])
+        forms.Add(text[p[0].Start:p[len(p)-1].End])
+        for _, s := range keys[i] {
+            freq[s]++
+            degree[s] += len(keys[i])
+        }
+    }
+
+    seen := make(map[string]bool)
+    res := make([]Keyword, 0)
+    for _, key := range keys {
+        stem := strings.Join(key, " ")
+        if seen[stem] {
+            continue
+        }
+        seen[stem] = true
+
+        score := 0.0
+        for _, s := range key {
+            score += float64(degree[s]) / float64(freq[s])
+        }
+        form, _ := forms.Unstem(stem)
+        res = append(res, Keyword{Stem: stem, Text: form, Score: score})
+    }
+    return best(res, k)
+}
@@ -0,0 +1,123 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package keyword
+
+import (
+    "bytes"
+    "reflect"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Sample text used by the tests.
+const article = "O governo federal anunciou novas medidas econômicas. " +
+    "As medidas econômicas do governo federal reduzem a taxa de juros. " +
+    "Segundo o governo, a taxa cairá. Os juros altos preocupam."
+
+// Create an extractor with a small background corpus.
+func sampleExtractor() *Extractor {
+    e := NewExtractor(ptstemmer.NewPorterStemmer())
+    e.Learn("O governo anunciou o orçamento.")
+    e.Learn("O governo federal e os estados.")
+    e.Learn("Chuvas fortes atingem a cidade.")
+    return e
+}
+
+// TestTFIDF checks if frequent words that are rare in the background are
+// ranked first and reported with their most frequent form.
+func TestTFIDF(t *testing.T) {
+    e := sampleExtractor()
+    keywords := e.TFIDF(article, 3)
+    if len(keywords) != 3 {
+        t.Fatalf("Wrong number of keywords: %v\n", keywords)
+    }
+
+    // Ties between equally frequent stems are broken alphabetically.
+    expected := []string{"econômicas", "juros", "medidas"}
+    for i, k := range keywords {
+        if k.Text != expected[i] {
+            t.Errorf("Wrong keyword. expected= %s actual= %v\n", expected[i], k)
+        }
+    }
+    if keywords[2].Stem != "med" || keywords[0].Score != keywords[2].Score {
+        t.Errorf("Wrong keyword. expected= med actual= %v\n", keywords[2])
+    }
+    if keywords[0].Score <= e.TFIDF(article, 10)[9].Score {
+        t.Errorf("Background words should score lower: %v\n", keywords)
+    }
+    if all := e.TFIDF(article, -1); len(all) <= 10 {
+        t.Errorf("Non positive k should return every stem: %v\n", all)
+    }
+}
+
+// TestRAKE checks if phrases delimited by stop words, punctuation and
+// the maximum phrase length are extracted and scored.
+func TestRAKE(t *testing.T) {
+    e := sampleExtractor()
+    keywords := e.RAKE(article, 4)
+
+    expected := []string{
+        "governo federal anunciou novas",
+        "governo federal reduzem",
+        "juros altos preocupam",
+        "medidas econômicas",
+    }
+    if len(keywords) != len(expected) {
+        t.Fatalf("Wrong number of keyphrases: %v\n", keywords)
+    }
+    for i, k := range keywords {
+        if k.Text != expected[i] {
+            t.Errorf("Wrong keyphrase. expected= %s actual= %v\n", expected[i], k)
+        }
+    }
+
+    all := e.RAKE(article, 0)
+    if len(all) <= len(expected) || !reflect.DeepEqual(all[:len(expected)], keywords) {
+        t.Errorf("Non positive k should return every phrase: %v\n", all)
+    }
+    if n := len(e.RAKE(article, -1)); n != len(all) {
+        t.Errorf("Wrong number of phrases for negative k: %d\n", n)
+    }
+}
+
+// TestBackground checks if a background survives a round trip.
+func TestBackground(t *testing.T) {
+    e := sampleExtractor()
+
+    var buf bytes.Buffer
+    if _, err := e.Background.WriteTo(&buf); err != nil {
+        t.Fatalf("Could not write background: %v\n", err)
+    }
+    b, err := ReadBackground(&buf)
+    if err != nil {
+        t.Fatalf("Could not read background: %v\n", err)
+    }
+    if b.Docs != 3 || b.DocFreq["govern"] != 2 || b.IDF("govern") != e.Background.IDF("govern") {
+        t.Errorf("Wrong background: %v\n", b)
+    }
+}
@@ -0,0 +1,190 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// Analysis is a possible reading of a word: its lemma along with the
+// part of speech tag found in the lexicon.
+type Analysis struct {
+    Lemma string // Dictionary form of the word
+    POS   string // Part of speech tag, as written in the lexicon
+}
+
+// Lemmatizer finds the dictionary form of words using a full-form
+// lexicon. Words not found in the lexicon are stemmed by a fallback
+// stemmer. Lemmatizer implements the Stemmer interface, so lemmas can be
+// used wherever stems are expected.
+type Lemmatizer struct {
+    entries  map[string][]Analysis // Analyses of each known form
+    fallback Stemmer               // Stemmer used for unknown words
+}
+
+// Create an empty lemmatizer. If fallback is nil, a PorterStemmer is used
+// for words that are not in the lexicon.
+func NewLemmatizer(fallback Stemmer) *Lemmatizer {
+    l := new(Lemmatizer)
+    l.entries = make(map[string][]Analysis)
+    if fallback == nil {
+        fallback = NewPorterStemmer()
+    }
+    l.fallback = fallback
+    return l
+}
+
+// Add an entry to the lexicon. Forms are stored in lower case and
+// repeated analyses are ignored.
+func (l *Lemmatizer) Add(form, lemma, pos string) {
+    form = strings.ToLower(form)
+    a := Analysis{Lemma: lemma, POS: pos}
+    for _, e := range l.entries[form] {
+        if e == a {
+            return
+        }
+    }
+    l.entries[form] = append(l.entries[form], a)
+}
+
+// LoadTSV reads a lexicon with one entry per line in the format
+//
+//      [form] TAB [lemma] TAB [pos]
+//
+// The pos column is optional. Empty lines and lines starting with '#'
+// are ignored.
+func (l *Lemmatizer) LoadTSV(r io.Reader) error {
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        text := strings.TrimRight(sc.Text(), "\r")
+        if text == "" || strings.HasPrefix(text, "#") {
+            continue
+        }
+
+        fields := strings.Split(text, "\t")
+        if len(fields) < 2 || len(fields) > 3 || fields[0] == "" || fields[1] == "" {
+            return fmt.Errorf("ptstemmer: line %d: invalid lexicon entry", line)
+        }
+
+        pos := ""
+        if len(fields) == 3 {
+            pos = fields[2]
+        }
+        l.Add(fields[0], fields[1], pos)
+    }
+    return sc.Err()
+}
+
+// LoadDELAF reads a lexicon in the DELAF format used by Unitex, with one
+// entry per line:
+//
+//      [form],[lemma].[pos]+[traits]:[inflection]
+//
+// An empty lemma means the lemma is the form itself. Characters escaped
+// with a backslash are read literally. Only the grammatical category is
+// kept as the part of speech.
+func (l *Lemmatizer) LoadDELAF(r io.Reader) error {
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        text := strings.TrimRight(sc.Text(), "\r")
+        if text == "" {
+            continue
+        }
+
+        form, rest, ok := cutUnescaped(text, ',')
+        if !ok || form == "" {
+            return fmt.Errorf("ptstemmer: line %d: invalid DELAF entry", line)
+        }
+        lemma, codes, ok := cutUnescaped(rest, '.')
+        if !ok {
+            return fmt.Errorf("ptstemmer: line %d: invalid DELAF entry", line)
+        }
+        if lemma == "" {
+            lemma = form
+        }
+
+        pos := codes
+        if i := strings.IndexAny(pos, "+:"); i >= 0 {
+            pos = pos[:i]
+        }
+        l.Add(form, lemma, pos)
+    }
+    return sc.Err()
+}
+
+// Split s at the first occurrence of sep not escaped by a backslash.
+// Escapes are removed from the part before the separator.
+func cutUnescaped(s string, sep rune) (string, string, bool) {
+    var b strings.Builder
+    escaped := false
+    for i, r := range s {
+        switch {
+        case escaped:
+            b.WriteRune(r)
+            escaped = false
+        case r == '\\':
+            escaped = true
+        case r == sep:
+            return b.String(), s[i+1:], true
+        default:
+            b.WriteRune(r)
+        }
+    }
+    return b.String(), "", false
+}
+
+// Analyze returns every analysis of the word found in the lexicon, in
+// the order they were loaded. Lookup is case insensitive. If the word is
+// unknown, nil is returned.
+func (l *Lemmatizer) Analyze(word string) []Analysis {
+    return l.entries[strings.ToLower(word)]
+}
+
+// Lemma returns the lemma of the first analysis of the word and true.
+// If the word is not in the lexicon, its stem according to the fallback
+// stemmer and false are returned.
+func (l *Lemmatizer) Lemma(word string) (string, bool) {
+    if a := l.Analyze(word); len(a) > 0 {
+        return a[0].Lemma, true
+    }
+    return l.fallback.Stem(strings.ToLower(word)), false
+}
+
+// Stem implements the Stemmer interface by returning the lemma of the
+// word, or its fallback stem for unknown words.
+func (l *Lemmatizer) Stem(word string) string {
+    lemma, _ := l.Lemma(word)
+    return lemma
+}
+
+// Len returns the number of distinct forms in the lexicon.
+func (l *Lemmatizer) Len() int {
+    return len(l.entries)
+}
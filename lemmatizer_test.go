@@ -0,0 +1,128 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "testing"
+)
+
+// TestLemmatizerTSV checks if a TSV lexicon is loaded and if unknown
+// words fall back to the Porter stemmer.
+func TestLemmatizerTSV(t *testing.T) {
+    lexicon := "# form lemma pos\n" +
+        "ajudaram\tajudar\tVERB\n" +
+        "fui\tir\tVERB\n" +
+        "fui\tser\tVERB\n" +
+        "casas\tcasa\tNOUN\n" +
+        "casas\tcasar\tVERB\n" +
+        "melhores\tbom\n"
+
+    l := NewLemmatizer(nil)
+    if err := l.LoadTSV(strings.NewReader(lexicon)); err != nil {
+        t.Fatalf("Could not load lexicon: %v\n", err)
+    }
+
+    var cases = []struct {
+        word  string
+        lemma string
+        known bool
+    }{
+        {"ajudaram", "ajudar", true},
+        {"Fui", "ir", true},
+        {"casas", "casa", true},
+        {"melhores", "bom", true},
+        {"ajudou", "ajud", false},
+    }
+
+    for _, c := range cases {
+        lemma, known := l.Lemma(c.word)
+        if lemma != c.lemma || known != c.known {
+            t.Errorf("Invalid lemma. word= %s expected= %s %v actual= %s %v\n",
+                c.word, c.lemma, c.known, lemma, known)
+        }
+    }
+
+    a := l.Analyze("fui")
+    if len(a) != 2 || a[1] != (Analysis{"ser", "VERB"}) {
+        t.Errorf("Invalid analyses. word= fui actual= %v\n", a)
+    }
+
+    var s Stemmer = l
+    if r := s.Stem("casas"); r != "casa" {
+        t.Errorf("Invalid stem. word= casas expected= casa actual= %s\n", r)
+    }
+
+    if err := l.LoadTSV(strings.NewReader("casas\n")); err == nil {
+        t.Errorf("Invalid lexicon accepted\n")
+    }
+}
+
+// TestLemmatizerDELAF checks if DELAF entries, including escapes and
+// empty lemmas, are loaded.
+func TestLemmatizerDELAF(t *testing.T) {
+    lexicon := "abacaxis,abacaxi.N:mp\n" +
+        "casa,.N+z1:fs\n" +
+        "casa,casar.V:P3s:Y2s\n" +
+        "d\\.C\\.,d\\.C\\..ABREV\n"
+
+    l := NewLemmatizer(nil)
+    if err := l.LoadDELAF(strings.NewReader(lexicon)); err != nil {
+        t.Fatalf("Could not load lexicon: %v\n", err)
+    }
+
+    var cases = []struct {
+        word     string
+        analyses []Analysis
+    }{
+        {"abacaxis", []Analysis{{"abacaxi", "N"}}},
+        {"casa", []Analysis{{"casa", "N"}, {"casar", "V"}}},
+        {"d.c.", []Analysis{{"d.C.", "ABREV"}}},
+    }
+
+    for _, c := range cases {
+        a := l.Analyze(c.word)
+        if len(a) != len(c.analyses) {
+            t.Errorf("Invalid analyses. word= %s expected= %v actual= %v\n",
+                c.word, c.analyses, a)
+            continue
+        }
+        for i := range a {
+            if a[i] != c.analyses[i] {
+                t.Errorf("Invalid analysis. word= %s expected= %v actual= %v\n",
+                    c.word, c.analyses[i], a[i])
+            }
+        }
+    }
+
+    if l.Len() != 3 {
+        t.Errorf("Wrong lexicon size. expected= 3 actual= %d\n", l.Len())
+    }
+    if err := l.LoadDELAF(strings.NewReader("casa\n")); err == nil {
+        t.Errorf("Invalid lexicon accepted\n")
+    }
+}
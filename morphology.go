@@ -0,0 +1,344 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode"
+)
+
+// POS is a part of speech, named by its Universal Dependencies tag.
+type POS string
+
+// Parts of speech.
+const (
+    POSNoun  POS = "NOUN"  // Noun
+    POSPropn POS = "PROPN" // Proper noun
+    POSAdj   POS = "ADJ"   // Adjective
+    POSAdv   POS = "ADV"   // Adverb
+    POSVerb  POS = "VERB"  // Verb
+    POSAux   POS = "AUX"   // Auxiliary verb
+    POSDet   POS = "DET"   // Determiner
+    POSPron  POS = "PRON"  // Pronoun
+    POSAdp   POS = "ADP"   // Preposition
+    POSCConj POS = "CCONJ" // Coordinating conjunction
+    POSSConj POS = "SCONJ" // Subordinating conjunction
+    POSNum   POS = "NUM"   // Numeral
+    POSX     POS = "X"     // Unknown
+)
+
+// IsOpen returns true for open word classes, whose words are built with
+// inflectional and derivational suffixes.
+func (p POS) IsOpen() bool {
+    return p == POSNoun || p == POSAdj || p == POSAdv || p == POSVerb
+}
+
+// Morphology is a guessed analysis of a word. Features use the values of
+// Universal Dependencies and are empty when unknown.
+type Morphology struct {
+    POS      POS    // Part of speech
+    Suffix   string // Suffix the guess is based on
+    Gender   string // Masc or Fem
+    Number   string // Sing or Plur
+    Person   string // 1, 2 or 3
+    Tense    string // Pres, Past, Imp, Pqp or Fut
+    Mood     string // Ind, Sub or Cnd
+    VerbForm string // Fin, Inf, Ger or Part
+}
+
+// Feats returns the known features in CoNLL-U format, such as
+// "Gender=Fem|Number=Sing", or "_" if none is known.
+func (m Morphology) Feats() string {
+    feats := []string{}
+    for _, f := range []struct{ name, value string }{
+        {"Gender", m.Gender}, {"Mood", m.Mood}, {"Number", m.Number},
+        {"Person", m.Person}, {"Tense", m.Tense}, {"VerbForm", m.VerbForm},
+    } {
+        if f.value != "" {
+            feats = append(feats, f.name+"="+f.value)
+        }
+    }
+    if len(feats) == 0 {
+        return "_"
+    }
+    return strings.Join(feats, "|")
+}
+
+// Parse features in CoNLL-U format into a morphology with the POS.
+func parseFeats(pos POS, suffix, feats string) Morphology {
+    m := Morphology{POS: pos, Suffix: suffix}
+    for _, f := range strings.Split(feats, "|") {
+        name, value, _ := strings.Cut(f, "=")
+        switch name {
+        case "Gender":
+            m.Gender = value
+        case "Number":
+            m.Number = value
+        case "Person":
+            m.Person = value
+        case "Tense":
+            m.Tense = value
+        case "Mood":
+            m.Mood = value
+        case "VerbForm":
+            m.VerbForm = value
+        }
+    }
+    return m
+}
+
+// Closed class words, which are not guessed by their suffixes.
+var closedClassWords = map[string]struct {
+    pos   POS
+    feats string
+}{
+    "o": {POSDet, "Gender=Masc|Number=Sing"}, "a": {POSDet, "Gender=Fem|Number=Sing"},
+    "os": {POSDet, "Gender=Masc|Number=Plur"}, "as": {POSDet, "Gender=Fem|Number=Plur"},
+    "um": {POSDet, "Gender=Masc|Number=Sing"}, "uma": {POSDet, "Gender=Fem|Number=Sing"},
+    "uns": {POSDet, "Gender=Masc|Number=Plur"}, "umas": {POSDet, "Gender=Fem|Number=Plur"},
+    "de": {POSAdp, ""}, "em": {POSAdp, ""}, "para": {POSAdp, ""}, "por": {POSAdp, ""},
+    "com": {POSAdp, ""}, "sem": {POSAdp, ""}, "sob": {POSAdp, ""}, "sobre": {POSAdp, ""},
+    "entre": {POSAdp, ""}, "até": {POSAdp, ""}, "desde": {POSAdp, ""}, "contra": {POSAdp, ""},
+    "após": {POSAdp, ""}, "perante": {POSAdp, ""},
+    "do": {POSAdp, ""}, "da": {POSAdp, ""}, "dos": {POSAdp, ""}, "das": {POSAdp, ""},
+    "no": {POSAdp, ""}, "na": {POSAdp, ""}, "nos": {POSAdp, ""}, "nas": {POSAdp, ""},
+    "ao": {POSAdp, ""}, "aos": {POSAdp, ""}, "à": {POSAdp, ""}, "às": {POSAdp, ""},
+    "pelo": {POSAdp, ""}, "pela": {POSAdp, ""}, "pelos": {POSAdp, ""}, "pelas": {POSAdp, ""},
+    "eu": {POSPron, "Number=Sing|Person=1"}, "tu": {POSPron, "Number=Sing|Person=2"},
+    "ele": {POSPron, "Gender=Masc|Number=Sing|Person=3"}, "ela": {POSPron, "Gender=Fem|Number=Sing|Person=3"},
+    "nós": {POSPron, "Number=Plur|Person=1"}, "vós": {POSPron, "Number=Plur|Person=2"},
+    "eles": {POSPron, "Gender=Masc|Number=Plur|Person=3"}, "elas": {POSPron, "Gender=Fem|Number=Plur|Person=3"},
+    "você": {POSPron, "Number=Sing|Person=3"}, "vocês": {POSPron, "Number=Plur|Person=3"},
+    "me": {POSPron, "Number=Sing|Person=1"}, "te": {POSPron, "Number=Sing|Person=2"},
+    "se": {POSPron, "Person=3"}, "lhe": {POSPron, "Number=Sing|Person=3"},
+    "lhes": {POSPron, "Number=Plur|Person=3"}, "isso": {POSPron, ""}, "isto": {POSPron, ""},
+    "aquilo": {POSPron, ""},
+    "e": {POSCConj, ""}, "ou": {POSCConj, ""}, "mas": {POSCConj, ""}, "nem": {POSCConj, ""},
+    "que": {POSSConj, ""}, "porque": {POSSConj, ""}, "quando": {POSSConj, ""},
+    "embora": {POSSConj, ""}, "como": {POSSConj, ""},
+}
+
+// Part of speech and features of suffixes checked in step 1.
+var step1Morphology = map[string]struct {
+    pos   POS
+    feats string
+}{
+    "eza": {POSNoun, "Gender=Fem|Number=Sing"}, "ezas": {POSNoun, "Gender=Fem|Number=Plur"},
+    "ico": {POSAdj, "Gender=Masc|Number=Sing"}, "ica": {POSAdj, "Gender=Fem|Number=Sing"},
+    "icos": {POSAdj, "Gender=Masc|Number=Plur"}, "icas": {POSAdj, "Gender=Fem|Number=Plur"},
+    "ismo": {POSNoun, "Gender=Masc|Number=Sing"}, "ismos": {POSNoun, "Gender=Masc|Number=Plur"},
+    "ável": {POSAdj, "Number=Sing"}, "ível": {POSAdj, "Number=Sing"},
+    "ista": {POSNoun, "Number=Sing"}, "istas": {POSNoun, "Number=Plur"},
+    "oso": {POSAdj, "Gender=Masc|Number=Sing"}, "osa": {POSAdj, "Gender=Fem|Number=Sing"},
+    "osos": {POSAdj, "Gender=Masc|Number=Plur"}, "osas": {POSAdj, "Gender=Fem|Number=Plur"},
+    "amento": {POSNoun, "Gender=Masc|Number=Sing"}, "amentos": {POSNoun, "Gender=Masc|Number=Plur"},
+    "imento": {POSNoun, "Gender=Masc|Number=Sing"}, "imentos": {POSNoun, "Gender=Masc|Number=Plur"},
+    "ador": {POSNoun, "Gender=Masc|Number=Sing"}, "adores": {POSNoun, "Gender=Masc|Number=Plur"},
+    "adora": {POSNoun, "Gender=Fem|Number=Sing"}, "adoras": {POSNoun, "Gender=Fem|Number=Plur"},
+    "aça~o": {POSNoun, "Gender=Fem|Number=Sing"}, "aço~es": {POSNoun, "Gender=Fem|Number=Plur"},
+    "ante": {POSAdj, "Number=Sing"}, "antes": {POSAdj, "Number=Plur"},
+    "ância": {POSNoun, "Gender=Fem|Number=Sing"},
+    "logía": {POSNoun, "Gender=Fem|Number=Sing"}, "logías": {POSNoun, "Gender=Fem|Number=Plur"},
+    "ución": {POSNoun, "Gender=Fem|Number=Sing"}, "uciones": {POSNoun, "Gender=Fem|Number=Plur"},
+    "ência": {POSNoun, "Gender=Fem|Number=Sing"}, "ências": {POSNoun, "Gender=Fem|Number=Plur"},
+    "amente": {POSAdv, ""}, "mente": {POSAdv, ""},
+    "idade": {POSNoun, "Gender=Fem|Number=Sing"}, "idades": {POSNoun, "Gender=Fem|Number=Plur"},
+    "iva": {POSAdj, "Gender=Fem|Number=Sing"}, "ivo": {POSAdj, "Gender=Masc|Number=Sing"},
+    "ivas": {POSAdj, "Gender=Fem|Number=Plur"}, "ivos": {POSAdj, "Gender=Masc|Number=Plur"},
+    "ira": {POSNoun, "Gender=Fem|Number=Sing"}, "iras": {POSNoun, "Gender=Fem|Number=Plur"},
+}
+
+// Return the part of speech and features of a step 1 suffix. Suffixes
+// missing from step1Morphology, such as ones added to rules read by
+// ParsePorterRules, are POSX without features.
+func step1MorphologyOf(suffix string) (POS, string) {
+    if m, ok := step1Morphology[suffix]; ok {
+        return m.pos, m.feats
+    }
+    return POSX, ""
+}
+
+// Features of verb suffixes checked in step 2. Suffixes that are also
+// common nominal endings, such as -as and -es, are left out and guessed
+// as nominals.
+var step2Morphology = map[string]string{
+    "ar": "VerbForm=Inf", "er": "VerbForm=Inf", "ir": "VerbForm=Inf",
+    "ando": "VerbForm=Ger", "endo": "VerbForm=Ger", "indo": "VerbForm=Ger",
+    "ado": "Gender=Masc|Number=Sing|VerbForm=Part", "ido": "Gender=Masc|Number=Sing|VerbForm=Part",
+    "ada": "Gender=Fem|Number=Sing|VerbForm=Part", "ida": "Gender=Fem|Number=Sing|VerbForm=Part",
+    "ados": "Gender=Masc|Number=Plur|VerbForm=Part", "idos": "Gender=Masc|Number=Plur|VerbForm=Part",
+    "adas": "Gender=Fem|Number=Plur|VerbForm=Part", "idas": "Gender=Fem|Number=Plur|VerbForm=Part",
+    "ava": "Mood=Ind|Number=Sing|Tense=Imp|VerbForm=Fin",
+    "avas": "Mood=Ind|Number=Sing|Person=2|Tense=Imp|VerbForm=Fin",
+    "ávamos": "Mood=Ind|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin",
+    "avam": "Mood=Ind|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin",
+    "íamos": "Mood=Ind|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin",
+    "iam": "Mood=Ind|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin",
+    "aria": "Mood=Cnd|Number=Sing|VerbForm=Fin", "eria": "Mood=Cnd|Number=Sing|VerbForm=Fin",
+    "iria": "Mood=Cnd|Number=Sing|VerbForm=Fin",
+    "arias": "Mood=Cnd|Number=Sing|Person=2|VerbForm=Fin", "erias": "Mood=Cnd|Number=Sing|Person=2|VerbForm=Fin",
+    "irias": "Mood=Cnd|Number=Sing|Person=2|VerbForm=Fin",
+    "aríamos": "Mood=Cnd|Number=Plur|Person=1|VerbForm=Fin", "eríamos": "Mood=Cnd|Number=Plur|Person=1|VerbForm=Fin",
+    "iríamos": "Mood=Cnd|Number=Plur|Person=1|VerbForm=Fin",
+    "aríeis": "Mood=Cnd|Number=Plur|Person=2|VerbForm=Fin", "eríeis": "Mood=Cnd|Number=Plur|Person=2|VerbForm=Fin",
+    "iríeis": "Mood=Cnd|Number=Plur|Person=2|VerbForm=Fin",
+    "ariam": "Mood=Cnd|Number=Plur|Person=3|VerbForm=Fin", "eriam": "Mood=Cnd|Number=Plur|Person=3|VerbForm=Fin",
+    "iriam": "Mood=Cnd|Number=Plur|Person=3|VerbForm=Fin",
+    "arei": "Mood=Ind|Number=Sing|Person=1|Tense=Fut|VerbForm=Fin", "erei": "Mood=Ind|Number=Sing|Person=1|Tense=Fut|VerbForm=Fin",
+    "irei": "Mood=Ind|Number=Sing|Person=1|Tense=Fut|VerbForm=Fin",
+    "arás": "Mood=Ind|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin", "erás": "Mood=Ind|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin",
+    "irás": "Mood=Ind|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin",
+    "ará": "Mood=Ind|Number=Sing|Person=3|Tense=Fut|VerbForm=Fin", "erá": "Mood=Ind|Number=Sing|Person=3|Tense=Fut|VerbForm=Fin",
+    "irá": "Mood=Ind|Number=Sing|Person=3|Tense=Fut|VerbForm=Fin",
+    "aremos": "Mood=Ind|Number=Plur|Person=1|Tense=Fut|VerbForm=Fin", "eremos": "Mood=Ind|Number=Plur|Person=1|Tense=Fut|VerbForm=Fin",
+    "iremos": "Mood=Ind|Number=Plur|Person=1|Tense=Fut|VerbForm=Fin",
+    "areis": "Mood=Ind|Number=Plur|Person=2|Tense=Fut|VerbForm=Fin", "ereis": "Mood=Ind|Number=Plur|Person=2|Tense=Fut|VerbForm=Fin",
+    "ireis": "Mood=Ind|Number=Plur|Person=2|Tense=Fut|VerbForm=Fin",
+    "ara~o": "Mood=Ind|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin", "era~o": "Mood=Ind|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin",
+    "ira~o": "Mood=Ind|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin",
+    "ara": "Mood=Ind|Number=Sing|Tense=Pqp|VerbForm=Fin", "era": "Mood=Ind|Number=Sing|Tense=Pqp|VerbForm=Fin",
+    "ira": "Mood=Ind|Number=Sing|Tense=Pqp|VerbForm=Fin",
+    "aras": "Mood=Ind|Number=Sing|Person=2|Tense=Pqp|VerbForm=Fin", "eras": "Mood=Ind|Number=Sing|Person=2|Tense=Pqp|VerbForm=Fin",
+    "iras": "Mood=Ind|Number=Sing|Person=2|Tense=Pqp|VerbForm=Fin",
+    "áramos": "Mood=Ind|Number=Plur|Person=1|Tense=Pqp|VerbForm=Fin", "éramos": "Mood=Ind|Number=Plur|Person=1|Tense=Pqp|VerbForm=Fin",
+    "íramos": "Mood=Ind|Number=Plur|Person=1|Tense=Pqp|VerbForm=Fin",
+    "áreis": "Mood=Ind|Number=Plur|Person=2|Tense=Pqp|VerbForm=Fin", "éreis": "Mood=Ind|Number=Plur|Person=2|Tense=Pqp|VerbForm=Fin",
+    "íreis": "Mood=Ind|Number=Plur|Person=2|Tense=Pqp|VerbForm=Fin",
+    "aram": "Mood=Ind|Number=Plur|Person=3|Tense=Past|VerbForm=Fin", "eram": "Mood=Ind|Number=Plur|Person=3|Tense=Past|VerbForm=Fin",
+    "iram": "Mood=Ind|Number=Plur|Person=3|Tense=Past|VerbForm=Fin",
+    "ei": "Mood=Ind|Number=Sing|Person=1|Tense=Past|VerbForm=Fin",
+    "aste": "Mood=Ind|Number=Sing|Person=2|Tense=Past|VerbForm=Fin", "este": "Mood=Ind|Number=Sing|Person=2|Tense=Past|VerbForm=Fin",
+    "iste": "Mood=Ind|Number=Sing|Person=2|Tense=Past|VerbForm=Fin",
+    "ou": "Mood=Ind|Number=Sing|Person=3|Tense=Past|VerbForm=Fin", "eu": "Mood=Ind|Number=Sing|Person=3|Tense=Past|VerbForm=Fin",
+    "iu": "Mood=Ind|Number=Sing|Person=3|Tense=Past|VerbForm=Fin",
+    "ámos": "Mood=Ind|Number=Plur|Person=1|Tense=Past|VerbForm=Fin",
+    "amos": "Number=Plur|Person=1|VerbForm=Fin", "emos": "Number=Plur|Person=1|VerbForm=Fin",
+    "imos": "Number=Plur|Person=1|VerbForm=Fin",
+    "astes": "Mood=Ind|Number=Plur|Person=2|Tense=Past|VerbForm=Fin", "estes": "Mood=Ind|Number=Plur|Person=2|Tense=Past|VerbForm=Fin",
+    "istes": "Mood=Ind|Number=Plur|Person=2|Tense=Past|VerbForm=Fin",
+    "asse": "Mood=Sub|Number=Sing|Tense=Imp|VerbForm=Fin", "esse": "Mood=Sub|Number=Sing|Tense=Imp|VerbForm=Fin",
+    "isse": "Mood=Sub|Number=Sing|Tense=Imp|VerbForm=Fin",
+    "asses": "Mood=Sub|Number=Sing|Person=2|Tense=Imp|VerbForm=Fin", "esses": "Mood=Sub|Number=Sing|Person=2|Tense=Imp|VerbForm=Fin",
+    "isses": "Mood=Sub|Number=Sing|Person=2|Tense=Imp|VerbForm=Fin",
+    "ássemos": "Mood=Sub|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin", "êssemos": "Mood=Sub|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin",
+    "íssemos": "Mood=Sub|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin",
+    "ásseis": "Mood=Sub|Number=Plur|Person=2|Tense=Imp|VerbForm=Fin", "ésseis": "Mood=Sub|Number=Plur|Person=2|Tense=Imp|VerbForm=Fin",
+    "ísseis": "Mood=Sub|Number=Plur|Person=2|Tense=Imp|VerbForm=Fin",
+    "assem": "Mood=Sub|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin", "essem": "Mood=Sub|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin",
+    "issem": "Mood=Sub|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin",
+    "arem": "Mood=Sub|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin", "erem": "Mood=Sub|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin",
+    "irem": "Mood=Sub|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin",
+    "ares": "Mood=Sub|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin", "eres": "Mood=Sub|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin",
+    "ires": "Mood=Sub|Number=Sing|Person=2|Tense=Fut|VerbForm=Fin",
+    "armos": "Number=Plur|Person=1|VerbForm=Inf", "ermos": "Number=Plur|Person=1|VerbForm=Inf",
+    "irmos": "Number=Plur|Person=1|VerbForm=Inf",
+    "ardes": "Number=Plur|Person=2|VerbForm=Inf", "erdes": "Number=Plur|Person=2|VerbForm=Inf",
+    "irdes": "Number=Plur|Person=2|VerbForm=Inf",
+    "am": "Number=Plur|Person=3|VerbForm=Fin", "em": "Number=Plur|Person=3|VerbForm=Fin",
+}
+
+// Nominal ending with its part of speech and features.
+type nominalEnding struct {
+    ending string
+    pos    POS
+    feats  string
+}
+
+// Endings that identify nominals even when they look like verb endings,
+// as in "viagem".
+var derivationalEndings = []nominalEnding{
+    {"áveis", POSAdj, "Number=Plur"}, {"íveis", POSAdj, "Number=Plur"},
+    {"ções", POSNoun, "Gender=Fem|Number=Plur"}, {"ção", POSNoun, "Gender=Fem|Number=Sing"},
+    {"sões", POSNoun, "Gender=Fem|Number=Plur"}, {"são", POSNoun, "Gender=Fem|Number=Sing"},
+    {"dades", POSNoun, "Gender=Fem|Number=Plur"}, {"dade", POSNoun, "Gender=Fem|Number=Sing"},
+    {"gens", POSNoun, "Gender=Fem|Number=Plur"}, {"gem", POSNoun, "Gender=Fem|Number=Sing"},
+    {"ores", POSNoun, "Gender=Masc|Number=Plur"},
+}
+
+// Gender and number endings of other nominals, longest first. Words
+// ending in s without a known ending are plural.
+var inflectionalEndings = []nominalEnding{
+    {"or", POSNoun, "Gender=Masc|Number=Sing"},
+    {"ões", POSNoun, "Number=Plur"}, {"ão", POSNoun, "Gender=Masc|Number=Sing"},
+    {"as", POSNoun, "Gender=Fem|Number=Plur"}, {"a", POSNoun, "Gender=Fem|Number=Sing"},
+    {"os", POSNoun, "Gender=Masc|Number=Plur"}, {"o", POSNoun, "Gender=Masc|Number=Sing"},
+    {"s", POSNoun, "Number=Plur"},
+}
+
+// Return the morphology of the first matching ending.
+func matchNominal(word string, endings []nominalEnding) (Morphology, bool) {
+    for _, e := range endings {
+        if strings.HasSuffix(word, e.ending) {
+            return parseFeats(e.pos, e.ending, e.feats), true
+        }
+    }
+    return Morphology{}, false
+}
+
+// GuessMorphology guesses the part of speech and features of a word
+// from the suffixes matched by the stemming steps. Step 1 suffixes
+// identify adverbs, nouns and adjectives, step 2 suffixes identify
+// verbs, and other words are guessed as nouns by their endings. Step 2
+// suffixes that are also common nominal endings, such as -as, are not
+// taken as evidence of verbs. Common closed class words are looked up in
+// a table.
+func (ps *PorterStemmer) GuessMorphology(word string) Morphology {
+    word = strings.ToLower(word)
+    if c, ok := closedClassWords[word]; ok {
+        return parseFeats(c.pos, "", c.feats)
+    }
+    if word == "" {
+        return Morphology{POS: POSX}
+    }
+    if strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+        return Morphology{POS: POSNum}
+    }
+
+    w := ps.expandNasalisedVowels(word)
+    r1 := ps.r(w)
+    rv := ps.rv(w)
+
+    if suffix, _ := ps.step1SuffixTree.LongestSuffix(w); suffix != "" && strings.HasSuffix(r1, suffix) {
+        pos, feats := step1MorphologyOf(suffix)
+        return parseFeats(pos, ps.contractNasalisedVowels(suffix), feats)
+    }
+
+    if m, ok := matchNominal(word, derivationalEndings); ok {
+        return m
+    }
+
+    if suffix, _ := ps.step2SuffixTree.LongestSuffix(rv); suffix != "" {
+        if feats, ok := step2Morphology[suffix]; ok {
+            return parseFeats(POSVerb, ps.contractNasalisedVowels(suffix), feats)
+        }
+    }
+
+    if m, ok := matchNominal(word, inflectionalEndings); ok {
+        return m
+    }
+    return parseFeats(POSNoun, "", "Number=Sing")
+}
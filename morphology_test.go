@@ -0,0 +1,93 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestGuessMorphology checks guessed parts of speech and features.
+func TestGuessMorphology(t *testing.T) {
+    tests := []struct {
+        word   string
+        pos    POS
+        suffix string
+        feats  string
+    }{
+        {"felizmente", POSAdv, "mente", "_"},
+        {"rapidamente", POSAdv, "amente", "_"},
+        {"belezas", POSNoun, "ezas", "Gender=Fem|Number=Plur"},
+        {"idealismo", POSNoun, "ismo", "Gender=Masc|Number=Sing"},
+        {"agradável", POSAdj, "ável", "Number=Sing"},
+        {"terríveis", POSAdj, "íveis", "Number=Plur"},
+        {"famosas", POSAdj, "osas", "Gender=Fem|Number=Plur"},
+        {"informação", POSNoun, "ação", "Gender=Fem|Number=Sing"},
+        {"soluções", POSNoun, "ções", "Gender=Fem|Number=Plur"},
+        {"viagem", POSNoun, "gem", "Gender=Fem|Number=Sing"},
+        {"falar", POSVerb, "ar", "VerbForm=Inf"},
+        {"falando", POSVerb, "ando", "VerbForm=Ger"},
+        {"faladas", POSVerb, "adas", "Gender=Fem|Number=Plur|VerbForm=Part"},
+        {"falávamos", POSVerb, "ávamos", "Mood=Ind|Number=Plur|Person=1|Tense=Imp|VerbForm=Fin"},
+        {"falarão", POSVerb, "arão", "Mood=Ind|Number=Plur|Person=3|Tense=Fut|VerbForm=Fin"},
+        {"falou", POSVerb, "ou", "Mood=Ind|Number=Sing|Person=3|Tense=Past|VerbForm=Fin"},
+        {"falassem", POSVerb, "assem", "Mood=Sub|Number=Plur|Person=3|Tense=Imp|VerbForm=Fin"},
+        {"casas", POSNoun, "as", "Gender=Fem|Number=Plur"},
+        {"canto", POSNoun, "o", "Gender=Masc|Number=Sing"},
+        {"professores", POSNoun, "ores", "Gender=Masc|Number=Plur"},
+        {"Eles", POSPron, "", "Gender=Masc|Number=Plur|Person=3"},
+        {"de", POSAdp, "", "_"},
+        {"2024", POSNum, "", "_"},
+        {"", POSX, "", "_"},
+    }
+
+    ps := NewPorterStemmer()
+    for _, test := range tests {
+        m := ps.GuessMorphology(test.word)
+        if m.POS != test.pos || m.Suffix != test.suffix || m.Feats() != test.feats {
+            t.Errorf("Wrong morphology of %s. expected= %s %s %s actual= %s %s %s\n",
+                test.word, test.pos, test.suffix, test.feats, m.POS, m.Suffix, m.Feats())
+        }
+    }
+}
+
+// TestMorphologyTables checks if every step 1 suffix has features.
+func TestMorphologyTables(t *testing.T) {
+    ps := NewPorterStemmer()
+    for _, suffix := range ps.step1SuffixTree.trie.Keys() {
+        if _, ok := step1Morphology[suffix]; !ok {
+            t.Errorf("Step 1 suffix without morphology: %s\n", suffix)
+        }
+    }
+    if !POSVerb.IsOpen() || POSDet.IsOpen() {
+        t.Errorf("Wrong open classes\n")
+    }
+
+    ps.step1SuffixTree.Add("douro", 0)
+    if m := ps.GuessMorphology("ancoradouro"); m.POS != POSX || m.Suffix != "douro" {
+        t.Errorf("Unlisted step 1 suffix should be POSX: %v\n", m)
+    }
+}
@@ -0,0 +1,297 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode"
+)
+
+// Unaccented form of uppercase portuguese letters. Ç is kept, since
+// encoders read it as S.
+var unaccented = map[rune]rune{
+    'Á': 'A', 'À': 'A', 'Â': 'A', 'Ã': 'A', 'Ä': 'A',
+    'É': 'E', 'È': 'E', 'Ê': 'E', 'Ë': 'E',
+    'Í': 'I', 'Ì': 'I', 'Î': 'I', 'Ï': 'I',
+    'Ó': 'O', 'Ò': 'O', 'Ô': 'O', 'Õ': 'O', 'Ö': 'O',
+    'Ú': 'U', 'Ù': 'U', 'Û': 'U', 'Ü': 'U',
+    'Ñ': 'N',
+}
+
+// Return the word in uppercase, without accents and without characters
+// that are not letters.
+func phoneticLetters(word string) []rune {
+    res := make([]rune, 0, len(word))
+    for _, r := range strings.ToUpper(word) {
+        if u, ok := unaccented[r]; ok {
+            r = u
+        }
+        if unicode.IsLetter(r) {
+            res = append(res, r)
+        }
+    }
+    return res
+}
+
+// Return true if the uppercase letter is a vowel.
+func isPhoneticVowel(r rune) bool {
+    return strings.ContainsRune("AEIOUY", r)
+}
+
+// Return the letters without adjacent repetitions.
+func squeezeRunes(letters []rune) []rune {
+    res := make([]rune, 0, len(letters))
+    for i, r := range letters {
+        if i == 0 || r != letters[i-1] {
+            res = append(res, r)
+        }
+    }
+    return res
+}
+
+// Sequential BuscaBR substitutions. Each step is applied to the whole
+// word before the next one.
+var buscaBRSteps = []*strings.Replacer{
+    strings.NewReplacer("Ç", "C"),
+    strings.NewReplacer("BL", "B", "BR", "B"),
+    strings.NewReplacer("PH", "F"),
+    strings.NewReplacer("GL", "G", "GR", "G", "MG", "G", "NG", "G", "RG", "G"),
+    strings.NewReplacer("Y", "I", "W", "V"),
+    strings.NewReplacer("GE", "J", "GI", "J", "RJ", "J", "MJ", "J"),
+    strings.NewReplacer("CA", "K", "CO", "K", "CU", "K", "CK", "K", "Q", "K"),
+    strings.NewReplacer("N", "M"),
+    strings.NewReplacer("AO", "M", "AUM", "M", "GM", "M", "MD", "M", "OM", "M", "ON", "M"),
+    strings.NewReplacer("PR", "P"),
+    strings.NewReplacer("L", "R"),
+    strings.NewReplacer("CE", "S", "CI", "S", "CH", "S", "CS", "S", "RS", "S",
+        "TS", "S", "X", "S", "Z", "S"),
+    strings.NewReplacer("TR", "T", "TL", "T", "CT", "T", "RT", "T", "ST", "T", "PT", "T"),
+}
+
+// Endings removed after the substitutions.
+var buscaBREndings = []string{"AO", "S", "Z", "R", "M", "N", "L"}
+
+// BuscaBR encodes words with the BuscaBR algorithm, designed for
+// brazilian names. Letters with similar sounds are merged by a sequence
+// of substitutions, and vowels are dropped, so the key of a word is made
+// only of consonants. Ç is read as C, as in the original algorithm, and
+// W as V.
+type BuscaBR struct{}
+
+// Encode returns the BuscaBR key of a word.
+func (BuscaBR) Encode(word string) string {
+    key := string(squeezeRunes(phoneticLetters(word)))
+    for _, step := range buscaBRSteps {
+        key = step.Replace(key)
+    }
+
+    for _, ending := range buscaBREndings {
+        if strings.HasSuffix(key, ending) {
+            key = key[:len(key)-len(ending)]
+            break
+        }
+    }
+    key = strings.ReplaceAll(key, "R", "L")
+
+    res := make([]rune, 0, len(key))
+    for _, r := range key {
+        if !isPhoneticVowel(r) && r != 'H' {
+            res = append(res, r)
+        }
+    }
+    return string(squeezeRunes(res))
+}
+
+// Metaphone encodes words with an adaptation of the Metaphone algorithm
+// to portuguese spelling. Vowels are kept only at the beginning of the
+// word, after a silent H, and the digraphs LH, NH and RR have their own
+// codes: 1, 3 and 2.
+type Metaphone struct {
+    MaxLength int // Truncate keys to this length, if positive
+}
+
+// Encode returns the Metaphone key of a word.
+func (m Metaphone) Encode(word string) string {
+    letters := phoneticLetters(word)
+    at := func(i int) rune {
+        if i < 0 || i >= len(letters) {
+            return 0
+        }
+        return letters[i]
+    }
+    frontVowel := func(r rune) bool {
+        return r == 'E' || r == 'I' || r == 'Y'
+    }
+
+    first := 0
+    if at(0) == 'H' {
+        first = 1
+    }
+
+    key := make([]rune, 0, len(letters))
+    for i := 0; i < len(letters); i++ {
+        r, next := letters[i], at(i+1)
+        if r == next && r != 'R' {
+            continue
+        }
+        switch r {
+        case 'A', 'E', 'I', 'O', 'U', 'Y':
+            if i == first {
+                key = append(key, unaccentedVowel(r))
+            }
+        case 'C':
+            switch {
+            case next == 'H':
+                key = append(key, 'X')
+                i++
+            case frontVowel(next):
+                key = append(key, 'S')
+            default:
+                key = append(key, 'K')
+            }
+        case 'Ç':
+            key = append(key, 'S')
+        case 'G':
+            switch {
+            case frontVowel(next):
+                key = append(key, 'J')
+            case next == 'U' && frontVowel(at(i+2)):
+                key = append(key, 'G')
+                i++
+            default:
+                key = append(key, 'G')
+            }
+        case 'H':
+            // Silent
+        case 'L':
+            if next == 'H' {
+                key = append(key, '1')
+                i++
+            } else {
+                key = append(key, 'L')
+            }
+        case 'N':
+            if next == 'H' {
+                key = append(key, '3')
+                i++
+            } else {
+                key = append(key, 'N')
+            }
+        case 'M':
+            if i == len(letters)-1 {
+                key = append(key, 'N')
+            } else {
+                key = append(key, 'M')
+            }
+        case 'P':
+            if next == 'H' {
+                key = append(key, 'F')
+                i++
+            } else {
+                key = append(key, 'P')
+            }
+        case 'Q':
+            key = append(key, 'K')
+            if next == 'U' {
+                i++
+            }
+        case 'R':
+            if i == first || next == 'R' {
+                key = append(key, '2')
+                if next == 'R' {
+                    i++
+                }
+            } else {
+                key = append(key, 'R')
+            }
+        case 'S':
+            switch {
+            case next == 'H':
+                key = append(key, 'X')
+                i++
+            case next == 'C' && frontVowel(at(i+2)):
+                key = append(key, 'S')
+                i++
+            case i > 0 && isPhoneticVowel(letters[i-1]) && isPhoneticVowel(next):
+                key = append(key, 'Z')
+            default:
+                key = append(key, 'S')
+            }
+        case 'T':
+            key = append(key, 'T')
+            if next == 'H' {
+                i++
+            }
+        case 'W':
+            key = append(key, 'V')
+        case 'X':
+            if i == 1 && letters[0] == 'E' && isPhoneticVowel(next) {
+                key = append(key, 'Z')
+            } else {
+                key = append(key, 'X')
+            }
+        case 'Z':
+            if i == len(letters)-1 {
+                key = append(key, 'S')
+            } else {
+                key = append(key, 'Z')
+            }
+        default:
+            key = append(key, r)
+        }
+    }
+
+    key = squeezeRunes(key)
+    if m.MaxLength > 0 && len(key) > m.MaxLength {
+        key = key[:m.MaxLength]
+    }
+    return string(key)
+}
+
+// Return the vowel written at the beginning of a key. Y is read as I.
+func unaccentedVowel(r rune) rune {
+    if r == 'Y' {
+        return 'I'
+    }
+    return r
+}
+
+// PhoneticFilter replaces the text of each word token by its phonetic
+// key.
+type PhoneticFilter struct {
+    Encoder PhoneticEncoder // Encoder applied to each token
+}
+
+// Filter encodes tokens in place and returns the same slice.
+func (f PhoneticFilter) Filter(tokens []Token) []Token {
+    for i := range tokens {
+        if tokens[i].Type == TokenWord {
+            tokens[i].Text = f.Encoder.Encode(tokens[i].Text)
+        }
+    }
+    return tokens
+}
@@ -0,0 +1,117 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "os"
+    "strings"
+    "testing"
+)
+
+// TestPhoneticCodes checks the keys of some words.
+func TestPhoneticCodes(t *testing.T) {
+    tests := []struct {
+        word, buscaBR, metaphone string
+    }{
+        {"Thiago", "TG", "TG"},
+        {"Souza", "S", "SZ"},
+        {"Conceição", "KMSK", "KNS"},
+        {"Guilherme", "GLM", "G1RM"},
+        {"Marinho", "MLM", "MR3"},
+        {"Barros", "BL", "B2S"},
+        {"Rocha", "LS", "2X"},
+        {"Exame", "SM", "EZM"},
+        {"", "", ""},
+    }
+
+    for _, test := range tests {
+        if key := (BuscaBR{}).Encode(test.word); key != test.buscaBR {
+            t.Errorf("Wrong BuscaBR key of %s. expected= %s actual= %s\n", test.word, test.buscaBR, key)
+        }
+        if key := (Metaphone{}).Encode(test.word); key != test.metaphone {
+            t.Errorf("Wrong Metaphone key of %s. expected= %s actual= %s\n", test.word, test.metaphone, key)
+        }
+    }
+
+    if key := (Metaphone{MaxLength: 2}).Encode("Guilherme"); key != "G1" {
+        t.Errorf("Wrong truncated key: %s\n", key)
+    }
+}
+
+// TestPhoneticNames checks if variants of the names corpus share their
+// keys, and if different names do not.
+func TestPhoneticNames(t *testing.T) {
+    file, err := os.Open("testdata/names.txt")
+    if err != nil {
+        t.Fatal(err)
+    }
+    defer file.Close()
+
+    encoders := map[string]PhoneticEncoder{
+        "BuscaBR":   BuscaBR{},
+        "Metaphone": Metaphone{},
+    }
+    groups := make(map[string]map[string]string)
+    for name := range encoders {
+        groups[name] = make(map[string]string)
+    }
+
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        names := strings.Fields(scanner.Text())
+        if len(names) == 0 || strings.HasPrefix(names[0], "#") {
+            continue
+        }
+
+        for name, encoder := range encoders {
+            key := encoder.Encode(names[0])
+            for _, variant := range names[1:] {
+                if k := encoder.Encode(variant); k != key {
+                    t.Errorf("%s: %s= %s %s= %s\n", name, names[0], key, variant, k)
+                }
+            }
+            groups[name][key] = names[0]
+        }
+    }
+    if err := scanner.Err(); err != nil {
+        t.Fatal(err)
+    }
+
+    if len(groups["Metaphone"]) < 24 {
+        t.Errorf("Too many Metaphone collisions: %v\n", groups["Metaphone"])
+    }
+}
+
+// TestPhoneticFilter checks if tokens are replaced by their keys.
+func TestPhoneticFilter(t *testing.T) {
+    a := &Analyzer{Filters: []TokenFilter{PhoneticFilter{Encoder: Metaphone{}}}}
+    terms := a.Terms("Thiago Souza")
+    if strings.Join(terms, " ") != "TG SZ" {
+        t.Errorf("Wrong terms: %v\n", terms)
+    }
+}
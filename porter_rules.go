@@ -0,0 +1,209 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// ParsePorterRules creates a Porter stemmer from a rule file. Each line
+// of the file holds a table name followed by its values, and lines
+// starting with '#' are comments:
+//
+//      vowels aeiouáéíóúâêô
+//      step1  [suffix] [group]
+//      step2  [suffix]
+//      step4  [suffix]
+//      step5  [suffix]
+//
+// The group of step1 suffixes selects the action taken when the suffix is
+// found, as in the default tables, and defaults to 0. The vowels line
+// replaces the whole vowel set, which defaults to the portuguese vowels.
+func ParsePorterRules(r io.Reader) (*PorterStemmer, error) {
+    ps := new(PorterStemmer)
+    ps.vowels = make(map[rune]bool)
+    for _, rn := range portugueseVowels {
+        ps.vowels[rn] = true
+    }
+    ps.step1SuffixTree = newSuffixTree()
+    ps.step2SuffixTree = newSuffixTree()
+    ps.step4SuffixTree = newSuffixTree()
+    ps.step5SuffixTree = newSuffixTree()
+
+    tables := map[string]*suffixTree{
+        "step1": ps.step1SuffixTree,
+        "step2": ps.step2SuffixTree,
+        "step4": ps.step4SuffixTree,
+        "step5": ps.step5SuffixTree,
+    }
+
+    sc := bufio.NewScanner(r)
+    for line := 1; sc.Scan(); line++ {
+        fields := strings.Fields(sc.Text())
+        if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+            continue
+        }
+
+        if fields[0] == "vowels" {
+            if len(fields) != 2 {
+                return nil, fmt.Errorf("ptstemmer: line %d: expected vowels", line)
+            }
+            ps.vowels = make(map[rune]bool)
+            for _, rn := range fields[1] {
+                ps.vowels[rn] = true
+            }
+            continue
+        }
+
+        st, ok := tables[fields[0]]
+        if !ok {
+            return nil, fmt.Errorf("ptstemmer: line %d: unknown table %q", line, fields[0])
+        }
+
+        group := 0
+        switch {
+        case len(fields) == 3 && fields[0] == "step1":
+            g, err := strconv.Atoi(fields[2])
+            if err != nil || g < 0 || g > 8 {
+                return nil, fmt.Errorf("ptstemmer: line %d: invalid group %q", line, fields[2])
+            }
+            group = g
+        case len(fields) != 2:
+            return nil, fmt.Errorf("ptstemmer: line %d: expected a single suffix", line)
+        }
+
+        st.Add(ps.expandNasalisedVowels(fields[1]), group)
+    }
+
+    if err := sc.Err(); err != nil {
+        return nil, err
+    }
+    return ps, nil
+}
+
+// WriteRules writes the tables of the stemmer in the format read by
+// ParsePorterRules. Prefix and diminutive settings are not included.
+func (ps *PorterStemmer) WriteRules(w io.Writer) error {
+    bw := bufio.NewWriter(w)
+    fmt.Fprintf(bw, "vowels %s\n", ps.vowelString())
+
+    tables := []struct {
+        name string
+        st   *suffixTree
+    }{
+        {"step1", ps.step1SuffixTree},
+        {"step2", ps.step2SuffixTree},
+        {"step4", ps.step4SuffixTree},
+        {"step5", ps.step5SuffixTree},
+    }
+    for _, t := range tables {
+        t.st.trie.Walk(func(suffix string, group int) bool {
+            suffix = ps.contractNasalisedVowels(suffix)
+            if t.name == "step1" {
+                fmt.Fprintf(bw, "%s %s %d\n", t.name, suffix, group)
+            } else {
+                fmt.Fprintf(bw, "%s %s\n", t.name, suffix)
+            }
+            return true
+        })
+    }
+    return bw.Flush()
+}
+
+// Return the vowels of the stemmer as a sorted string.
+func (ps *PorterStemmer) vowelString() string {
+    vowels := make([]rune, 0, len(ps.vowels))
+    for rn := range ps.vowels {
+        vowels = append(vowels, rn)
+    }
+    sort.Slice(vowels, func(i, j int) bool { return vowels[i] < vowels[j] })
+    return string(vowels)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// encoding holds the stemmer tables along with the prefix stripper and
+// diminutive reducer, if enabled.
+func (ps *PorterStemmer) MarshalBinary() ([]byte, error) {
+    w := newBinaryWriter(kindPorterStemmer)
+    w.putString(ps.vowelString())
+    ps.step1SuffixTree.encode(w)
+    ps.step2SuffixTree.encode(w)
+    ps.step4SuffixTree.encode(w)
+    ps.step5SuffixTree.encode(w)
+
+    w.putBool(ps.prefixes != nil)
+    if ps.prefixes != nil {
+        ps.prefixes.encode(w)
+    }
+    w.putBool(ps.diminutives != nil)
+    if ps.diminutives != nil {
+        ps.diminutives.encode(w)
+    }
+    return w.bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface,
+// replacing the whole configuration of the stemmer.
+func (ps *PorterStemmer) UnmarshalBinary(data []byte) error {
+    r, err := newBinaryReader(data, kindPorterStemmer)
+    if err != nil {
+        return err
+    }
+
+    res := new(PorterStemmer)
+    res.vowels = make(map[rune]bool)
+    for _, rn := range r.string() {
+        res.vowels[rn] = true
+    }
+
+    res.step1SuffixTree = newSuffixTree()
+    res.step2SuffixTree = newSuffixTree()
+    res.step4SuffixTree = newSuffixTree()
+    res.step5SuffixTree = newSuffixTree()
+    res.step1SuffixTree.decode(r)
+    res.step2SuffixTree.decode(r)
+    res.step4SuffixTree.decode(r)
+    res.step5SuffixTree.decode(r)
+
+    if r.bool() {
+        res.prefixes = decodePrefixStripper(r)
+    }
+    if r.bool() {
+        res.diminutives = decodeDiminutiveReducer(r)
+    }
+
+    if err := r.close(); err != nil {
+        return err
+    }
+    *ps = *res
+    return nil
+}
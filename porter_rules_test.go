@@ -0,0 +1,115 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "bytes"
+    "os"
+    "strings"
+    "testing"
+)
+
+// Checks if a stemmer written as rules and parsed back, and a stemmer
+// loaded from its binary encoding, behave as the original.
+func TestPorterRules(t *testing.T) {
+    ps := NewPorterStemmer()
+    ps.SetPrefixStripper(NewPrefixStripper())
+    ps.SetDiminutiveReducer(NewDiminutiveReducer())
+
+    var buf bytes.Buffer
+    if err := ps.WriteRules(&buf); err != nil {
+        t.Fatalf("Could not write rules: %v\n", err)
+    }
+    parsed, err := ParsePorterRules(&buf)
+    if err != nil {
+        t.Fatalf("Could not parse rules: %v\n", err)
+    }
+    parsed.SetPrefixStripper(NewPrefixStripper())
+    parsed.SetDiminutiveReducer(NewDiminutiveReducer())
+
+    data, err := parsed.MarshalBinary()
+    if err != nil {
+        t.Fatalf("Could not marshal stemmer: %v\n", err)
+    }
+    loaded := new(PorterStemmer)
+    if err := loaded.UnmarshalBinary(data); err != nil {
+        t.Fatalf("Could not unmarshal stemmer: %v\n", err)
+    }
+
+    ip, err := os.Open("testdata/ptstems.txt")
+    if err != nil {
+        t.Fatalf("Could not open test file: testdata/ptstems.txt")
+    }
+    defer ip.Close()
+
+    sc := bufio.NewScanner(ip)
+    for sc.Scan() {
+        word := strings.Fields(sc.Text())[0]
+        expected := ps.Stem(word)
+        if r := parsed.Stem(word); r != expected {
+            t.Fatalf("Invalid parsed stem. word= %s expected= %s actual= %s",
+                word, expected, r)
+        }
+        if r := loaded.Stem(word); r != expected {
+            t.Fatalf("Invalid loaded stem. word= %s expected= %s actual= %s",
+                word, expected, r)
+        }
+    }
+}
+
+// Checks if invalid rule files are rejected.
+func TestParsePorterRulesErrors(t *testing.T) {
+    invalid := []string{
+        "step3 ci\n",
+        "step1 eza 9\n",
+        "step2 ada 0\n",
+        "vowels\n",
+    }
+
+    for _, rules := range invalid {
+        if _, err := ParsePorterRules(strings.NewReader(rules)); err == nil {
+            t.Errorf("Invalid rules accepted: %q\n", rules)
+        }
+    }
+
+    ps, err := ParsePorterRules(strings.NewReader("# only verbs\nvowels aeiou\nstep2 ar\n"))
+    if err != nil {
+        t.Fatalf("Could not parse rules: %v\n", err)
+    }
+    if r := ps.Stem("cantar"); r != "cant" {
+        t.Errorf("Invalid stem. word= cantar expected= cant actual= %s\n", r)
+    }
+
+    ps, err = ParsePorterRules(strings.NewReader("step2 ar\n"))
+    if err != nil {
+        t.Fatalf("Could not parse rules: %v\n", err)
+    }
+    if r := ps.Stem("cantar"); r != "cant" {
+        t.Errorf("Rules without vowels should use the default vowels. actual= %s\n", r)
+    }
+}
@@ -26,7 +26,13 @@
 
 package ptstemmer
 
-import "strings"
+import (
+    "strings"
+    "unicode/utf8"
+)
+
+// Vowels of the portuguese language used by the Porter stemmer.
+const portugueseVowels = "aeiouáéíóúâêô"
 
 // PorterStemmer implements the Porter stemming algorithm for the
 // portuguese language.
@@ -38,6 +44,9 @@ type PorterStemmer struct {
     step2SuffixTree *suffixTree   // Suffixes checked in step2
     step4SuffixTree *suffixTree   // Suffixes checked in step4
     step5SuffixTree *suffixTree   // Suffixes checked in step5
+
+    prefixes    *PrefixStripper    // Optional prefix removal
+    diminutives *DiminutiveReducer // Optional diminutive reduction
 }
 
 // Create Porter stemmer struct. Vowels and necessary suffixes for the
@@ -47,8 +56,7 @@ func NewPorterStemmer() *PorterStemmer {
 
     // Load portuguese vowels.
     ps.vowels = make(map[rune]bool)
-    vowels := "aeiouáéíóúâêô"
-    vowelsRunes := []rune(vowels)
+    vowelsRunes := []rune(portugueseVowels)
     for _, rn := range vowelsRunes {
         ps.vowels[rn] = true
     }
@@ -156,6 +164,18 @@ func NewPorterStemmer() *PorterStemmer {
     return ps
 }
 
+// Enable diminutive and augmentative reduction before the stemming
+// steps. Passing nil disables the reduction.
+func (ps *PorterStemmer) SetDiminutiveReducer(d *DiminutiveReducer) {
+    ps.diminutives = d
+}
+
+// Enable prefix removal before the stemming steps. Passing nil disables
+// the removal.
+func (ps *PorterStemmer) SetPrefixStripper(p *PrefixStripper) {
+    ps.prefixes = p
+}
+
 // Return true if letter is a vowel. Otherwise it should be treated
 // as a consonant.
 func (ps *PorterStemmer) isVowel(r rune) bool {
@@ -454,18 +474,125 @@ func (ps *PorterStemmer) step5(word, r1, r2, rv string) (string, bool) {
 // Stem executes all steps necessary to obtain a given word's stem. This
 // function is used for portuguese stemming only.
 func (ps *PorterStemmer) Stem(word string) string {
+    return ps.stem(word, POSX, nil)
+}
+
+// StemAs stems a word known to have the given part of speech. Only the
+// steps and suffix groups suitable for it are applied: verbs skip the
+// derivational suffixes of step 1, nouns and adjectives skip adverb
+// suffixes and, unless a derivational suffix is removed, are only
+// reduced to their singular, so "casa" and "canto" keep the vowel that
+// separates them from the verbs "casar" and "cantar". Adverbs only
+// remove -mente. Words of closed classes and proper
+// nouns are returned unchanged, and POSX stems as Stem.
+func (ps *PorterStemmer) StemAs(word string, pos POS) string {
+    switch pos {
+    case POSNoun, POSAdj, POSAdv, POSVerb, POSAux, POSX, "":
+        return ps.stem(word, pos, nil)
+    }
+    return word
+}
+
+// Plural endings of nouns and adjectives, with nasalised vowels
+// expanded, and the singular ending replacing each of them. Longer
+// endings come first, and -res is only replaced after a vowel.
+var nominalPlurals = [][2]string{
+    {"o~es", "a~o"}, {"a~es", "a~o"}, {"a~os", "a~o"},
+    {"ais", "al"}, {"éis", "el"}, {"eis", "el"}, {"óis", "ol"}, {"uis", "ul"},
+    {"res", "r"}, {"zes", "z"}, {"ns", "m"},
+}
+
+// Return true if step 1 may remove a suffix of the group from a word
+// with the part of speech.
+func step1Allowed(group int, pos POS) bool {
+    adverb := group == 4 || group == 5
+    switch pos {
+    case POSVerb, POSAux:
+        return false
+    case POSNoun, POSAdj:
+        return !adverb
+    case POSAdv:
+        return adverb
+    }
+    return true
+}
+
+// Reduce a noun or adjective to its singular. Returns the resultant
+// word and a boolean indicating if the word was modified.
+func (ps *PorterStemmer) nominalSingular(word string) (string, bool) {
+    for _, p := range nominalPlurals {
+        base, ok := strings.CutSuffix(word, p[0])
+        if !ok || utf8.RuneCountInString(base) < 2 {
+            continue
+        }
+        if last, _ := utf8.DecodeLastRuneInString(base); p[0] == "res" && !ps.isVowel(last) {
+            continue
+        }
+        return base + p[1], true
+    }
+
+    base, ok := strings.CutSuffix(word, "s")
+    if last, _ := utf8.DecodeLastRuneInString(base); ok && utf8.RuneCountInString(base) >= 2 && ps.isVowel(last) {
+        return base, true
+    }
+    return word, false
+}
+
+// Stem a word, restricting the steps to those suitable for the part of
+// speech. If trace is not nil, it is called with the name of every step
+// that changed the word and the word before and after the step, with
+// nasalised vowels contracted.
+func (ps *PorterStemmer) stem(word string, pos POS, trace func(step, before, after string)) string {
+    record := func(step, before, after string) {
+        if trace != nil && before != after {
+            trace(step, ps.contractNasalisedVowels(before), ps.contractNasalisedVowels(after))
+        }
+    }
+
+    if ps.prefixes != nil {
+        before := word
+        word = ps.prefixes.Strip(word)
+        record("prefix", before, word)
+    }
+    if ps.diminutives != nil {
+        before := word
+        word = ps.diminutives.Reduce(word)
+        record("diminutive", before, word)
+    }
+
     stem := ps.expandNasalisedVowels(word)
+    before := stem
     modified := false
     r1 := ps.r(stem)
     r2 := ps.r(r1)
     rv := ps.rv(stem)
 
-    // Always do step 1.
-    stem, modified = ps.step1(stem, r1, r2, rv)
+    // Always do step 1, if the suffix group suits the part of speech.
+    if _, group := ps.step1SuffixTree.LongestSuffix(stem); step1Allowed(group, pos) {
+        stem, modified = ps.step1(stem, r1, r2, rv)
+        record("step1", before, stem)
+        before = stem
+    }
+
+    // Adverbs only lose -mente and its variants, later steps would cut
+    // adverbs such as "cedo" and "ontem".
+    if pos == POSAdv {
+        return ps.contractNasalisedVowels(stem)
+    }
+
+    // Nouns and adjectives without a derivational suffix are only
+    // reduced to their singular, keeping their final vowel.
+    if !modified && (pos == POSNoun || pos == POSAdj) {
+        stem, _ = ps.nominalSingular(stem)
+        record("step2", before, stem)
+        return ps.contractNasalisedVowels(stem)
+    }
 
     // Do step 2 if no ending was removed by step 1.
     if !modified {
         stem, modified = ps.step2(stem, r1, r2, rv)
+        record("step2", before, stem)
+        before = stem
     }
 
     // Update R1, R2, RV if modified
@@ -477,11 +604,14 @@ func (ps *PorterStemmer) Stem(word string) string {
         rv = ps.rv(stem)
 
         stem, modified = ps.step3(stem, r1, r2, rv)
+        record("step3", before, stem)
     } else {
         // Alternatively, if neither steps 1 nor 2 altered the word, 
         // do step 4.
         stem, modified = ps.step4(stem, r1, r2, rv)
+        record("step4", before, stem)
     }
+    before = stem
 
     if modified {
         r1 = ps.r(stem)
@@ -491,6 +621,7 @@ func (ps *PorterStemmer) Stem(word string) string {
 
     // Always do step 5.
     stem, modified = ps.step5(stem, r1, r2, rv)
+    record("step5", before, stem)
     stem = ps.contractNasalisedVowels(stem)
     return stem
 }
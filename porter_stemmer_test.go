@@ -178,3 +178,52 @@ func TestFile(t *testing.T) {
         }
     }
 }
+
+// TestStemAs checks if the part of speech restricts the stemming steps.
+func TestStemAs(t *testing.T) {
+    var cases = []struct {
+        word string
+        pos  POS
+        stem string
+    }{
+        {"lugar", POSNoun, "lugar"},
+        {"lugares", POSNoun, "lugar"},
+        {"jantar", POSNoun, "jantar"},
+        {"jantares", POSNoun, "jantar"},
+        {"jantar", POSVerb, "jant"},
+        {"jantaram", POSVerb, "jant"},
+        {"casas", POSNoun, "casa"},
+        {"casa", POSNoun, "casa"},
+        {"casar", POSVerb, "cas"},
+        {"casa", POSVerb, "cas"},
+        {"canto", POSNoun, "canto"},
+        {"cantos", POSNoun, "canto"},
+        {"canto", POSVerb, "cant"},
+        {"cantar", POSVerb, "cant"},
+        {"corações", POSNoun, "coração"},
+        {"jornais", POSNoun, "jornal"},
+        {"flores", POSNoun, "flor"},
+        {"padres", POSNoun, "padre"},
+        {"informações", POSNoun, "inform"},
+        {"famosas", POSAdj, "famosa"},
+        {"felizes", POSAdj, "feliz"},
+        {"rapidamente", POSAdv, "rapid"},
+        {"rapidamente", POSNoun, "rapidamente"},
+        {"cedo", POSAdv, "cedo"},
+        {"ontem", POSAdv, "ontem"},
+        {"cedo", POSX, "ced"},
+        {"cantante", POSVerb, "cantant"},
+        {"cantávamos", POSAux, "cant"},
+        {"o", POSDet, "o"},
+        {"Lisboa", POSPropn, "Lisboa"},
+        {"jantares", POSX, "jant"},
+    }
+
+    ps := NewPorterStemmer()
+    for _, c := range cases {
+        if stem := ps.StemAs(c.word, c.pos); stem != c.stem {
+            t.Errorf("Invalid stem. word= %s pos= %s expected= %s actual= %s\n",
+                c.word, c.pos, c.stem, stem)
+        }
+    }
+}
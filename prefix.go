@@ -0,0 +1,148 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode/utf8"
+)
+
+// PrefixStripper removes derivational prefixes such as 'des', 're' and
+// 'anti', so that "desfazer", "refazer" and "fazer" conflate. It can be
+// used as a standalone Stemmer or enabled in a PorterStemmer with
+// SetPrefixStripper.
+type PrefixStripper struct {
+    prefixes      *prefixTree // Known prefixes
+    exceptions    *prefixTree // Word beginnings that are never stripped
+    MinStemLength int         // Minimum runes left after removal
+}
+
+// Create a prefix stripper with the default portuguese prefixes and
+// exceptions.
+func NewPrefixStripper() *PrefixStripper {
+    p := new(PrefixStripper)
+    p.prefixes = newPrefixTree()
+    p.exceptions = newPrefixTree()
+    p.MinStemLength = 4
+
+    // Group 0 prefixes are removed before any letter, group 1 prefixes
+    // only before 'p' and 'b'.
+    p.AddPrefix("anti", 0).AddPrefix("auto", 0).AddPrefix("contra", 0)
+    p.AddPrefix("des", 0).AddPrefix("extra", 0).AddPrefix("hiper", 0)
+    p.AddPrefix("in", 0).AddPrefix("inter", 0).AddPrefix("mega", 0)
+    p.AddPrefix("micro", 0).AddPrefix("mini", 0).AddPrefix("multi", 0)
+    p.AddPrefix("pós", 0).AddPrefix("pré", 0).AddPrefix("pre", 0)
+    p.AddPrefix("re", 0).AddPrefix("semi", 0).AddPrefix("sobre", 0)
+    p.AddPrefix("sub", 0).AddPrefix("super", 0).AddPrefix("ultra", 0)
+    p.AddPrefix("im", 1)
+
+    p.AddException("autor", "desde", "desej", "desenh", "desert", "despes",
+        "despach", "destin", "destac", "impost", "imprens", "impress",
+        "implic", "import", "inform", "inici", "incl", "indic", "indústr",
+        "industr", "ingred", "instant", "instânc", "instit", "instal",
+        "intens", "intenç", "inteir", "integr", "interess", "interior",
+        "intern", "invent", "invern", "invest", "ingl", "real", "rebeld",
+        "receb", "recent", "recurs", "rede", "redor", "refer", "regi",
+        "regul", "relaç", "relat", "remed", "repúbl", "reserv", "respe",
+        "respo", "result", "resum", "prepar", "presen", "presid", "press",
+        "precis", "prefer", "prefeit", "prend", "prem", "prest", "pret",
+        "subst", "superaç")
+
+    return p
+}
+
+// Add a prefix that should be removed. Group 0 prefixes are removed
+// before any letter and group 1 prefixes only before 'p' and 'b'.
+// Returns the stripper to allow chained calls.
+func (p *PrefixStripper) AddPrefix(prefix string, group int) *PrefixStripper {
+    p.prefixes.Add(prefix, group)
+    return p
+}
+
+// Add word beginnings that should never be stripped. Any word starting
+// with one of the given strings is kept unchanged, so "interess" protects
+// both "interesse" and "interessante". Exceptions are compared without
+// diacritics, so "remed" protects "remédio". Returns the stripper to
+// allow chained calls.
+func (p *PrefixStripper) AddException(beginnings ...string) *PrefixStripper {
+    for _, b := range beginnings {
+        p.exceptions.Add(StripDiacritics(b), 0)
+    }
+    return p
+}
+
+// Strip removes the longest known prefix of the word. Words starting
+// with an exception, ignoring diacritics, and words that would become
+// shorter than MinStemLength or have no vowel left, are returned
+// unchanged.
+func (p *PrefixStripper) Strip(word string) string {
+    if e, _ := p.exceptions.LongestPrefix(StripDiacritics(word)); e != "" {
+        return word
+    }
+
+    prefix, group := p.prefixes.LongestPrefix(word)
+    if prefix == "" {
+        return word
+    }
+
+    stem := word[len(prefix):]
+    if utf8.RuneCountInString(stem) < p.MinStemLength ||
+        !strings.ContainsAny(stem, "aeiouáéíóúâêôãõ") {
+        return word
+    }
+
+    if group == 1 && !strings.HasPrefix(stem, "p") &&
+        !strings.HasPrefix(stem, "b") {
+        return word
+    }
+
+    return stem
+}
+
+// Stem implements the Stemmer interface, stripping the word without any
+// further stemming.
+func (p *PrefixStripper) Stem(word string) string {
+    return p.Strip(word)
+}
+
+// Write the prefixes, exceptions and settings of the stripper.
+func (p *PrefixStripper) encode(w *binaryWriter) {
+    p.prefixes.encode(w)
+    p.exceptions.encode(w)
+    w.putVarint(int64(p.MinStemLength))
+}
+
+// Create a stripper from the data written by encode.
+func decodePrefixStripper(r *binaryReader) *PrefixStripper {
+    p := new(PrefixStripper)
+    p.prefixes = newPrefixTree()
+    p.exceptions = newPrefixTree()
+    p.prefixes.decode(r)
+    p.exceptions.decode(r)
+    p.MinStemLength = int(r.varint())
+    return p
+}
@@ -0,0 +1,92 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestStrip checks if prefixes are removed only when the remaining word
+// is long enough and not protected by an exception.
+func TestStrip(t *testing.T) {
+    var cases = []struct {
+        word     string
+        stripped string
+    }{
+        {"desfazer", "fazer"},
+        {"refazer", "fazer"},
+        {"fazer", "fazer"},
+        {"antivírus", "vírus"},
+        {"impossível", "possível"},
+        {"imagem", "imagem"},
+        {"inútil", "útil"},
+        {"rever", "rever"},
+        {"resto", "resto"},
+        {"interessante", "interessante"},
+        {"desenho", "desenho"},
+        {"supermercado", "mercado"},
+        {"remédio", "remédio"},
+        {"remédios", "remédios"},
+        {"ingrediente", "ingrediente"},
+        {"inverno", "inverno"},
+        {"superação", "superação"},
+        {"reserva", "reserva"},
+        {"rebelde", "rebelde"},
+        {"indústria", "indústria"},
+        {"pressão", "pressão"},
+        {"recurso", "recurso"},
+        {"preparar", "preparar"},
+        {"instante", "instante"},
+    }
+
+    p := NewPrefixStripper()
+    for _, c := range cases {
+        r := p.Strip(c.word)
+        if r != c.stripped {
+            t.Errorf("Invalid strip. word= %s expected= %s actual= %s\n",
+                c.word, c.stripped, r)
+        }
+    }
+}
+
+// TestPrefixStemmer checks if prefixed words conflate when the stripper
+// is enabled in the Porter stemmer.
+func TestPrefixStemmer(t *testing.T) {
+    ps := NewPorterStemmer()
+    if ps.Stem("desfazer") == ps.Stem("fazer") {
+        t.Errorf("Prefix removal should be disabled by default\n")
+    }
+
+    ps.SetPrefixStripper(NewPrefixStripper())
+    stem := ps.Stem("fazer")
+    for _, w := range []string{"desfazer", "refazer", "desfazendo"} {
+        if r := ps.Stem(w); r != stem {
+            t.Errorf("Invalid stem. word= %s expected= %s actual= %s\n",
+                w, stem, r)
+        }
+    }
+}
@@ -0,0 +1,88 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "github.com/tncardoso/ptstemmer/trie"
+)
+
+// A prefix tree used to identify the longest known prefix in a given
+// word. It is the forward counterpart of suffixTree and stores a group
+// identifier along with each prefix.
+type prefixTree struct {
+    trie *trie.PrefixTrie[int] // Prefixes and their groups
+}
+
+// Create a new empty prefix tree.
+func newPrefixTree() *prefixTree {
+    t := new(prefixTree)
+    t.trie = trie.NewPrefixTrie[int]()
+    return t
+}
+
+// Add a new prefix to the tree. The group value is used to identify the
+// category of the prefix.
+func (pt *prefixTree) Add(word string, group int) *prefixTree {
+    pt.trie.Add(word, group)
+    return pt
+}
+
+// Returns true if a given word is already stored in the prefix tree.
+func (pt *prefixTree) Contains(word string) bool {
+    return pt.trie.Contains(word)
+}
+
+// Returns the longest known prefix that matches the given word. If no
+// prefix is found, empty string "" and group id -1 are returned. If a known
+// prefix matches the word, it is returned along with its category id.
+func (pt *prefixTree) LongestPrefix(word string) (string, int) {
+    prefix, group, ok := pt.trie.LongestMatch(word)
+    if !ok {
+        return "", -1
+    }
+    return prefix, group
+}
+
+// Write the prefixes of the tree and their groups.
+func (pt *prefixTree) encode(w *binaryWriter) {
+    w.putUvarint(uint64(pt.trie.Len()))
+    pt.trie.Walk(func(prefix string, group int) bool {
+        w.putString(prefix)
+        w.putVarint(int64(group))
+        return true
+    })
+}
+
+// Add the prefixes written by encode to the tree.
+func (pt *prefixTree) decode(r *binaryReader) {
+    n := r.uvarint()
+    for i := uint64(0); i < n && r.err == nil; i++ {
+        prefix := r.string()
+        group := r.varint()
+        pt.Add(prefix, int(group))
+    }
+}
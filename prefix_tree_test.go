@@ -0,0 +1,95 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// Checks if words are being correctly inserted and retrieved from the
+// prefix tree.
+func TestPrefixContains(t *testing.T) {
+    addedWords := []string{
+        "des",
+        "de",
+        "anti",
+        "ação"}
+    notAddedWords := []string{
+        "d",
+        "ant",
+        "antis",
+        "aç"}
+
+    pt := newPrefixTree()
+    for _, w := range addedWords {
+        pt.Add(w, 0)
+    }
+
+    for _, w := range addedWords {
+        if !pt.Contains(w) {
+            t.Errorf("Missing word: %s\n", w)
+        }
+    }
+
+    for _, w := range notAddedWords {
+        if pt.Contains(w) {
+            t.Errorf("False positive word: %s\n", w)
+        }
+    }
+}
+
+// Checks if the longest prefix is being correctly retrieved from the
+// prefix tree.
+func TestLongestPrefix(t *testing.T) {
+    var cases = []struct {
+        word   string
+        prefix string
+        group  int
+    }{
+        {"fazer", "", -1},
+        {"refazer", "re", 0},
+        {"desfazer", "des", 1},
+        {"deter", "de", 2},
+        {"interromper", "inter", 3},
+        {"inútil", "in", 4},
+    }
+
+    pt := newPrefixTree()
+    pt.Add("re", 0).Add("des", 1).Add("de", 2).Add("inter", 3).Add("in", 4)
+
+    for _, c := range cases {
+        r, g := pt.LongestPrefix(c.word)
+        if r != c.prefix {
+            t.Errorf("Wrong prefix. word= %s expected= %s returned= %s\n",
+                c.word, c.prefix, r)
+        }
+        if g != c.group {
+            t.Errorf("Wrong group. expected= %d returned= %d\n",
+                c.group, g)
+        }
+    }
+}
@@ -0,0 +1,187 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode/utf8"
+)
+
+// Affix is a part of a word removed or replaced by a stemming step.
+type Affix struct {
+    Text        string // Text removed from the word
+    Replacement string // Text added in its place, as "ente" for -ência
+    Prefix      bool   // True for prefixes, false for suffixes
+    Step        string // Step that changed the word: prefix, diminutive or step1 to step5
+    Category    string // Kind of affix, such as adverbial or verbal
+}
+
+// Segmentation is a word split into its stem and the affixes removed by
+// the stemmer, in the order they were removed.
+type Segmentation struct {
+    Word    string  // Original word
+    Stem    string  // Stem of the word
+    Affixes []Affix // Removed affixes, in order of removal
+}
+
+// Categories of affixes removed by step 1, by part of speech of the
+// matched suffix. Suffixes of other parts of speech are derivational.
+var step1Categories = map[POS]string{
+    POSAdv:  "adverbial",
+    POSNoun: "nominal",
+    POSAdj:  "adjectival",
+}
+
+// Categories of affixes removed by the other steps. Step 2 suffixes are
+// verbal only when they are verb endings, other suffixes removed by
+// step 2, such as plural endings, are inflectional.
+var stepCategories = map[string]string{
+    "prefix":     "prefix",
+    "diminutive": "diminutive",
+    "step2":      "inflectional",
+    "step3":      "residual",
+    "step4":      "residual",
+    "step5":      "residual",
+}
+
+// Return the length in bytes of the longest common prefix of a and b,
+// at rune boundaries.
+func commonPrefixLen(a, b string) int {
+    n := 0
+    for n < len(a) && n < len(b) {
+        ar, size := utf8.DecodeRuneInString(a[n:])
+        if br, _ := utf8.DecodeRuneInString(b[n:]); ar != br {
+            break
+        }
+        n += size
+    }
+    return n
+}
+
+// Return the length in bytes of the longest common suffix of a and b,
+// at rune boundaries.
+func commonSuffixLen(a, b string) int {
+    n := 0
+    for n < len(a) && n < len(b) {
+        ar, size := utf8.DecodeLastRuneInString(a[:len(a)-n])
+        if br, _ := utf8.DecodeLastRuneInString(b[:len(b)-n]); ar != br {
+            break
+        }
+        n += size
+    }
+    return n
+}
+
+// Segment returns the stem of a word along with every affix removed or
+// replaced by the stemming steps. Applying the affixes back to the stem,
+// with Reconstruct, gives the original word.
+func (ps *PorterStemmer) Segment(word string) Segmentation {
+    res := Segmentation{Word: word, Affixes: []Affix{}}
+    res.Stem = ps.stem(word, POSX, func(step, before, after string) {
+        a := Affix{Step: step, Category: stepCategories[step]}
+        if step == "prefix" {
+            n := commonSuffixLen(before, after)
+            a.Text = before[:len(before)-n]
+            a.Replacement = after[:len(after)-n]
+            a.Prefix = true
+        } else {
+            n := commonPrefixLen(before, after)
+            a.Text = before[n:]
+            a.Replacement = after[n:]
+        }
+
+        if step == "step1" {
+            suffix, _ := ps.step1SuffixTree.LongestSuffix(ps.expandNasalisedVowels(before))
+            pos, _ := step1MorphologyOf(suffix)
+            if a.Category = step1Categories[pos]; a.Category == "" {
+                a.Category = "derivational"
+            }
+        }
+        if step == "step2" {
+            if _, ok := step2Morphology[ps.expandNasalisedVowels(a.Text)]; ok {
+                a.Category = "verbal"
+            }
+        }
+        res.Affixes = append(res.Affixes, a)
+    })
+    return res
+}
+
+// Reconstruct returns the original word, undoing each affix removal on
+// the stem in reverse order.
+func (s Segmentation) Reconstruct() string {
+    word := s.Stem
+    for i := len(s.Affixes) - 1; i >= 0; i-- {
+        a := s.Affixes[i]
+        if a.Prefix {
+            word = a.Text + strings.TrimPrefix(word, a.Replacement)
+        } else {
+            word = strings.TrimSuffix(word, a.Replacement) + a.Text
+        }
+    }
+    return word
+}
+
+// Remove n bytes from the end of the segments, or from their beginning
+// if head is true, dropping segments that become empty.
+func trimSegments(segments []string, n int, head bool) []string {
+    for n > 0 && len(segments) > 0 {
+        i := len(segments) - 1
+        if head {
+            i = 0
+        }
+        seg := segments[i]
+        cut := min(n, len(seg))
+        n -= cut
+        if head {
+            segments[i] = seg[cut:]
+        } else {
+            segments[i] = seg[:len(seg)-cut]
+        }
+        if segments[i] == "" {
+            segments = append(segments[:i], segments[i+1:]...)
+        }
+    }
+    return segments
+}
+
+// String returns the original word split in segments separated by '+':
+// prefixes, the stem and suffixes. Text replaced by a step is shown as
+// in the original word, so the segments always join to the word.
+func (s Segmentation) String() string {
+    segments := []string{s.Stem}
+    for i := len(s.Affixes) - 1; i >= 0; i-- {
+        a := s.Affixes[i]
+        segments = trimSegments(segments, len(a.Replacement), a.Prefix)
+        if a.Prefix {
+            segments = append([]string{a.Text}, segments...)
+        } else {
+            segments = append(segments, a.Text)
+        }
+    }
+    return strings.Join(segments, "+")
+}
@@ -0,0 +1,126 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// TestSegment checks the removed affixes and their categories.
+func TestSegment(t *testing.T) {
+    tests := []struct {
+        word     string
+        stem     string
+        segments string
+        affixes  []Affix
+    }{
+        {"felizmente", "feliz", "feliz+mente", []Affix{
+            {Text: "mente", Step: "step1", Category: "adverbial"},
+        }},
+        {"existência", "existent", "exist+ência", []Affix{
+            {Text: "ência", Replacement: "ente", Step: "step1", Category: "nominal"},
+            {Text: "e", Step: "step5", Category: "residual"},
+        }},
+        {"agradável", "agrad", "agrad+ável", []Affix{
+            {Text: "ável", Step: "step1", Category: "adjectival"},
+        }},
+        {"cantávamos", "cant", "cant+ávamos", []Affix{
+            {Text: "ávamos", Step: "step2", Category: "verbal"},
+        }},
+        {"irmão", "irmã", "irmã+o", []Affix{
+            {Text: "o", Step: "step4", Category: "residual"},
+        }},
+        {"maçã", "maçã", "maçã", []Affix{}},
+    }
+
+    ps := NewPorterStemmer()
+    for _, test := range tests {
+        s := ps.Segment(test.word)
+        if s.Stem != test.stem || s.String() != test.segments || !reflect.DeepEqual(s.Affixes, test.affixes) {
+            t.Errorf("Wrong segmentation of %s. expected= %s %s %v actual= %s %s %v\n",
+                test.word, test.stem, test.segments, test.affixes, s.Stem, s, s.Affixes)
+        }
+        if s.Stem != ps.Stem(test.word) {
+            t.Errorf("Segment and Stem differ for %s\n", test.word)
+        }
+    }
+}
+
+// TestSegmentAffixes checks prefix and diminutive segments.
+func TestSegmentAffixes(t *testing.T) {
+    ps := NewPorterStemmer()
+    ps.SetPrefixStripper(NewPrefixStripper())
+    ps.SetDiminutiveReducer(NewDiminutiveReducer())
+
+    s := ps.Segment("desfazer")
+    if s.String() != "des+faz+er" || !s.Affixes[0].Prefix || s.Affixes[0].Category != "prefix" {
+        t.Errorf("Wrong segmentation: %s %v\n", s, s.Affixes)
+    }
+
+    s = ps.Segment("cartinhas")
+    expected := []Affix{
+        {Text: "inhas", Replacement: "as", Step: "diminutive", Category: "diminutive"},
+        {Text: "as", Step: "step2", Category: "inflectional"},
+    }
+    if s.String() != "cart+inhas" || !reflect.DeepEqual(s.Affixes, expected) {
+        t.Errorf("Wrong segmentation: %s %v\n", s, s.Affixes)
+    }
+}
+
+// TestSegmentUnlistedSuffix checks the category of step 1 suffixes
+// without known morphology, such as ones added to parsed rules.
+func TestSegmentUnlistedSuffix(t *testing.T) {
+    ps := NewPorterStemmer()
+    ps.step1SuffixTree.Add("douro", 0)
+
+    s := ps.Segment("ancoradouro")
+    expected := []Affix{{Text: "douro", Step: "step1", Category: "derivational"}}
+    if !reflect.DeepEqual(s.Affixes, expected) {
+        t.Errorf("Wrong affixes. expected= %v actual= %v\n", expected, s.Affixes)
+    }
+}
+
+// TestReconstruct checks if every word of the test vocabulary can be
+// rebuilt from its segmentation.
+func TestReconstruct(t *testing.T) {
+    ps := NewPorterStemmer()
+    ps.SetPrefixStripper(NewPrefixStripper())
+    ps.SetDiminutiveReducer(NewDiminutiveReducer())
+
+    for _, word := range strings.Fields("informações criativamente ciências " +
+        "pêssego ação logías reconstrução menininhos carrões inúteis") {
+        s := ps.Segment(word)
+        if r := s.Reconstruct(); r != word {
+            t.Errorf("Wrong reconstruction. expected= %s actual= %s %v\n", word, r, s.Affixes)
+        }
+        if j := strings.ReplaceAll(s.String(), "+", ""); j != word {
+            t.Errorf("Segments do not join to the word. expected= %s actual= %s\n", word, j)
+        }
+    }
+}
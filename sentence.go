@@ -0,0 +1,216 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// PortugueseAbbreviations lists common portuguese abbreviations written
+// with a trailing period, without the period. Single letters, as in
+// initials and "p. ex.", are always abbreviations.
+var PortugueseAbbreviations = []string{
+    "sr", "sra", "srs", "sras", "srta", "dr", "dra", "drs", "dras",
+    "prof", "profa", "profs", "eng", "adv", "arq", "dep", "sen", "gov",
+    "pe", "sto", "sta", "exmo", "exma", "exa", "av", "pç", "lg", "trav",
+    "ex", "vs", "pág", "pag", "págs", "pp", "pg", "cap", "vol", "ed",
+    "núm", "art", "inc", "parág", "fig", "tab", "cf", "obs", "tel", "cel",
+    "aprox", "máx", "mín", "séc", "depto", "op", "cit", "id", "ib",
+    "jan", "fev", "abr", "jun", "jul", "ago", "set", "out", "nov",
+}
+
+// Abbreviations that often end a sentence. They end one when followed
+// by an uppercase word.
+var portugueseFinalAbbreviations = []string{"etc", "ltda", "cia"}
+
+// Closing punctuation that stays in the sentence after its terminator.
+const sentenceClosers = "\"')]}»”’"
+
+// Sentence is a span of text. Start and End are byte offsets in the
+// original text, as in Token.
+type Sentence struct {
+    Text  string // Sentence text, without surrounding spaces
+    Start int    // Offset of the first byte in the original text
+    End   int    // Offset after the last byte in the original text
+}
+
+// Tokens returns the words of the sentence, with offsets in the original
+// text.
+func (s Sentence) Tokens() []Token {
+    tokens := Tokenize(s.Text)
+    for i := range tokens {
+        tokens[i].Start += s.Start
+        tokens[i].End += s.Start
+    }
+    return tokens
+}
+
+// SentenceSplitter splits text in sentences. Sentences end at '.', '!',
+// '?' or '…' followed by a space and an uppercase letter, digit or
+// opening punctuation, and at blank lines. Periods of abbreviations,
+// ordinals such as "1.º" and numbers do not end sentences.
+type SentenceSplitter struct {
+    abbreviations map[string]bool // Abbreviations, true if they may end a sentence
+}
+
+// Create a splitter with the given abbreviations. If abbreviations is
+// nil, PortugueseAbbreviations and a few abbreviations that may end a
+// sentence, such as "etc", are used.
+func NewSentenceSplitter(abbreviations []string) *SentenceSplitter {
+    s := new(SentenceSplitter)
+    s.abbreviations = make(map[string]bool)
+    if abbreviations == nil {
+        s.Add(PortugueseAbbreviations...)
+        s.AddFinal(portugueseFinalAbbreviations...)
+    } else {
+        s.Add(abbreviations...)
+    }
+    return s
+}
+
+// Add abbreviations that never end a sentence, such as "Sr". Returns the
+// splitter to allow chained calls.
+func (s *SentenceSplitter) Add(abbreviations ...string) *SentenceSplitter {
+    for _, a := range abbreviations {
+        s.abbreviations[strings.ToLower(strings.TrimSuffix(a, "."))] = false
+    }
+    return s
+}
+
+// AddFinal adds abbreviations that end a sentence when followed by an
+// uppercase word, such as "etc". Returns the splitter to allow chained
+// calls.
+func (s *SentenceSplitter) AddFinal(abbreviations ...string) *SentenceSplitter {
+    for _, a := range abbreviations {
+        s.abbreviations[strings.ToLower(strings.TrimSuffix(a, "."))] = true
+    }
+    return s
+}
+
+// IsAbbreviation returns true if the word, with or without the trailing
+// period, is a known abbreviation or a single letter.
+func (s *SentenceSplitter) IsAbbreviation(word string) bool {
+    word = strings.ToLower(strings.TrimSuffix(word, "."))
+    if utf8.RuneCountInString(word) == 1 {
+        r, _ := utf8.DecodeRuneInString(word)
+        return unicode.IsLetter(r)
+    }
+    _, ok := s.abbreviations[word]
+    return ok
+}
+
+// Return the word before a period at offset i.
+func wordBefore(text string, i int) string {
+    start := i
+    for start > 0 {
+        r, size := utf8.DecodeLastRuneInString(text[:start])
+        if !isWordRune(r) {
+            break
+        }
+        start -= size
+    }
+    return text[start:i]
+}
+
+// Return true if a sentence may start with the rune.
+func startsSentence(r rune) bool {
+    return unicode.IsUpper(r) || unicode.IsDigit(r) || strings.ContainsRune("\"'(«“‘—-¿¡", r)
+}
+
+// Return the end of the sentence terminator starting at offset i, after
+// any repeated terminators and closing punctuation, and true if a
+// sentence ends there.
+func (s *SentenceSplitter) boundary(text string, i int) (int, bool) {
+    r, size := utf8.DecodeRuneInString(text[i:])
+    end := i + size
+    for end < len(text) {
+        next, n := utf8.DecodeRuneInString(text[end:])
+        if !strings.ContainsRune(".!?…", next) && !strings.ContainsRune(sentenceClosers, next) {
+            break
+        }
+        end += n
+    }
+
+    // The terminator must be followed by a space and a sentence start.
+    rest := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+    if rest == "" {
+        return end, true
+    }
+    if len(rest) == len(text[end:]) {
+        return end, false
+    }
+    next, _ := utf8.DecodeRuneInString(rest)
+    if !startsSentence(next) {
+        return end, false
+    }
+
+    if r == '.' && end == i+size {
+        word := wordBefore(text, i)
+        if s.IsAbbreviation(word) {
+            return end, s.abbreviations[strings.ToLower(word)]
+        }
+    }
+    return end, true
+}
+
+// Split returns the sentences of a text.
+func (s *SentenceSplitter) Split(text string) []Sentence {
+    res := make([]Sentence, 0)
+    appendSentence := func(start, end int) {
+        raw := text[start:end]
+        trimmed := strings.TrimLeftFunc(raw, unicode.IsSpace)
+        start += len(raw) - len(trimmed)
+        trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+        if trimmed != "" {
+            res = append(res, Sentence{Text: trimmed, Start: start, End: start + len(trimmed)})
+        }
+    }
+
+    start := 0
+    for i := 0; i < len(text); {
+        r, size := utf8.DecodeRuneInString(text[i:])
+        switch {
+        case strings.ContainsRune(".!?…", r):
+            end, ok := s.boundary(text, i)
+            if ok {
+                appendSentence(start, end)
+                start = end
+            }
+            i = end
+        case r == '\n' && strings.HasPrefix(strings.TrimLeft(text[i+1:], " \t\r"), "\n"):
+            appendSentence(start, i)
+            start = i
+            i += size
+        default:
+            i += size
+        }
+    }
+    appendSentence(start, len(text))
+    return res
+}
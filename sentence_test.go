@@ -0,0 +1,100 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "testing"
+)
+
+// TestSentenceSplitter checks sentence boundaries around abbreviations,
+// ordinals, numbers and quotes.
+func TestSentenceSplitter(t *testing.T) {
+    tests := []struct {
+        text     string
+        expected []string
+    }{
+        {"", []string{}},
+        {"Sem ponto final", []string{"Sem ponto final"}},
+        {"O Sr. Silva chegou. A Dra. Souza saiu.", []string{"O Sr. Silva chegou.", "A Dra. Souza saiu."}},
+        {"Mora na Av. Paulista, p. ex. no n.º 1.500. Fim.", []string{"Mora na Av. Paulista, p. ex. no n.º 1.500.", "Fim."}},
+        {"Ficou em 1.º lugar com 9,5 pontos! Ganhou.", []string{"Ficou em 1.º lugar com 9,5 pontos!", "Ganhou."}},
+        {"Comprou frutas etc. Depois saiu.", []string{"Comprou frutas etc.", "Depois saiu."}},
+        {"Comprou frutas etc. e saiu.", []string{"Comprou frutas etc. e saiu."}},
+        {"Saiu... e voltou?! Sim.", []string{"Saiu... e voltou?!", "Sim."}},
+        {"\"Você vem?\" Ela disse que sim.", []string{"\"Você vem?\"", "Ela disse que sim."}},
+        {"J. R. R. Tolkien escreveu.", []string{"J. R. R. Tolkien escreveu."}},
+        {"Título\n\nTexto do corpo.", []string{"Título", "Texto do corpo."}},
+        {"Em 2020. 30 pessoas vieram.", []string{"Em 2020.", "30 pessoas vieram."}},
+    }
+
+    s := NewSentenceSplitter(nil)
+    for _, test := range tests {
+        sentences := s.Split(test.text)
+        res := make([]string, len(sentences))
+        for i, sent := range sentences {
+            res[i] = sent.Text
+            if test.text[sent.Start:sent.End] != sent.Text {
+                t.Errorf("Offsets do not match text. sentence= %v\n", sent)
+            }
+        }
+        if !reflect.DeepEqual(res, test.expected) {
+            t.Errorf("Wrong sentences of %q.\nexpected= %q\nactual= %q\n", test.text, test.expected, res)
+        }
+    }
+}
+
+// TestSentenceAbbreviations checks custom abbreviation lists.
+func TestSentenceAbbreviations(t *testing.T) {
+    s := NewSentenceSplitter([]string{"Gal."})
+    if !s.IsAbbreviation("gal") || s.IsAbbreviation("Sr.") || !s.IsAbbreviation("A.") {
+        t.Errorf("Wrong abbreviations\n")
+    }
+
+    text := "O Gal. Osório chegou. O Sr. Silva também."
+    expected := []string{"O Gal. Osório chegou.", "O Sr.", "Silva também."}
+    sentences := s.Split(text)
+    res := make([]string, len(sentences))
+    for i, sent := range sentences {
+        res[i] = sent.Text
+    }
+    if !reflect.DeepEqual(res, expected) {
+        t.Errorf("Wrong sentences. expected= %q actual= %q\n", expected, res)
+    }
+}
+
+// TestSentenceTokens checks if sentence tokens have offsets in the
+// original text.
+func TestSentenceTokens(t *testing.T) {
+    text := "Primeira frase. Segunda frase!"
+    sentences := NewSentenceSplitter(nil).Split(text)
+    tokens := sentences[1].Tokens()
+    expected := []Token{{"Segunda", 16, 23, TokenWord}, {"frase", 24, 29, TokenWord}}
+    if !reflect.DeepEqual(tokens, expected) {
+        t.Errorf("Wrong tokens. expected= %v actual= %v\n", expected, tokens)
+    }
+}
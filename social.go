@@ -0,0 +1,428 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "regexp"
+    "strings"
+    "unicode"
+    "unicode/utf8"
+)
+
+// DefaultAbbreviations maps common abbreviations of informal brazilian
+// portuguese to the words they stand for.
+var DefaultAbbreviations = map[string][]string{
+    "vc":   {"você"},
+    "vcs":  {"vocês"},
+    "q":    {"que"},
+    "pq":   {"porque"},
+    "tb":   {"também"},
+    "tbm":  {"também"},
+    "n":    {"não"},
+    "nao":  {"não"},
+    "naum": {"não"},
+    "eh":   {"é"},
+    "mt":   {"muito"},
+    "mto":  {"muito"},
+    "mta":  {"muita"},
+    "td":   {"tudo"},
+    "tds":  {"todos"},
+    "hj":   {"hoje"},
+    "agr":  {"agora"},
+    "dps":  {"depois"},
+    "qdo":  {"quando"},
+    "qnd":  {"quando"},
+    "cmg":  {"comigo"},
+    "ctz":  {"certeza"},
+    "vdd":  {"verdade"},
+    "msm":  {"mesmo"},
+    "blz":  {"beleza"},
+    "obg":  {"obrigado"},
+    "vlw":  {"valeu"},
+    "kd":   {"cadê"},
+    "bjs":  {"beijos"},
+    "sdds": {"saudades"},
+    "pfv":  {"por", "favor"},
+    "pfvr": {"por", "favor"},
+    "fds":  {"fim", "de", "semana"},
+}
+
+// Laughter written as repeated syllables, such as kkkk, rsrs or hahaha.
+var laughterPattern = regexp.MustCompile(`^(k{3,}|(rs){2,}r?|(h[aeiu]){2,}h?|(hue){2,}|(ks){2,}k?)$`)
+
+// IsLaughter returns true if the word is written laughter.
+func IsLaughter(word string) bool {
+    return laughterPattern.MatchString(strings.ToLower(word))
+}
+
+// SqueezeElongations shortens letters repeated three or more times, as
+// in "muuuuito". Repeated r and s are kept doubled, since rr and ss are
+// valid, and other letters are kept once. Digits and other characters
+// are never squeezed.
+func SqueezeElongations(word string) string {
+    return squeezeElongations(word, nil)
+}
+
+// Squeeze elongated letters as SqueezeElongations. If double is not nil,
+// it is called with the index of every elongated run of o or e, counted
+// from 0, and the run is kept doubled when it returns true.
+func squeezeElongations(word string, double func(run int) bool) string {
+    runes := []rune(word)
+    res := make([]rune, 0, len(runes))
+    run := 0
+    for i := 0; i < len(runes); {
+        j := i
+        for j < len(runes) && unicode.ToLower(runes[j]) == unicode.ToLower(runes[i]) {
+            j++
+        }
+        n := j - i
+        if n >= 3 && unicode.IsLetter(runes[i]) {
+            r := unicode.ToLower(runes[i])
+            n = 1
+            if r == 'r' || r == 's' {
+                n = 2
+            } else if r == 'o' || r == 'e' {
+                if double != nil && double(run) {
+                    n = 2
+                }
+                run++
+            }
+        }
+        res = append(res, runes[i:i+n]...)
+        i = j
+    }
+    return string(res)
+}
+
+// Return true if the rune is an emoji or pictographic symbol.
+func isEmoji(r rune) bool {
+    return unicode.Is(unicode.So, r) ||
+        r >= 0x1F300 && r <= 0x1FAFF ||
+        r >= 0x2600 && r <= 0x27BF ||
+        r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// Return true if the rune modifies the preceding emoji, such as skin
+// tones and variation selectors.
+func isEmojiModifier(r rune) bool {
+    return r >= 0x1F3FB && r <= 0x1F3FF || r == 0xFE0F || r == 0x20E3
+}
+
+// Return true if the rune can be part of a mention or hashtag.
+func isHandleRune(r rune) bool {
+    return isWordRune(r) || r == '_'
+}
+
+// Characters trimmed from the end of web addresses.
+const urlTrailing = ".,;:!?)]}\"'"
+
+// TokenizeSocial splits social media text in tokens. Besides words, it
+// finds web addresses, user mentions, hashtags and emoji, typed as
+// TokenURL, TokenMention, TokenHashtag and TokenEmoji. Emoji joined by
+// zero width joiners or followed by modifiers form a single token.
+func TokenizeSocial(text string) []Token {
+    tokens := make([]Token, 0)
+    prev := ' '
+    for i := 0; i < len(text); {
+        r, size := utf8.DecodeRuneInString(text[i:])
+        end := i + size
+        tt := TokenWord
+        startsWord := !isHandleRune(prev)
+
+        lower := strings.ToLower(text[i:])
+        switch {
+        case startsWord && (strings.HasPrefix(lower, "http://") ||
+            strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "www.")):
+            end = i + strings.IndexFunc(text[i:]+" ", unicode.IsSpace)
+            end = i + len(strings.TrimRight(text[i:end], urlTrailing))
+            tt = TokenURL
+        case startsWord && (r == '@' || r == '#') && end < len(text):
+            j := end
+            for j < len(text) {
+                rr, s := utf8.DecodeRuneInString(text[j:])
+                if !isHandleRune(rr) {
+                    break
+                }
+                j += s
+            }
+            if j == end {
+                i, prev = end, r
+                continue
+            }
+            end = j
+            tt = TokenMention
+            if r == '#' {
+                tt = TokenHashtag
+            }
+        case isEmoji(r):
+            regional := r >= 0x1F1E6 && r <= 0x1F1FF
+            for end < len(text) {
+                next, s := utf8.DecodeRuneInString(text[end:])
+                switch {
+                case isEmojiModifier(next):
+                    end += s
+                    continue
+                case regional && next >= 0x1F1E6 && next <= 0x1F1FF:
+                    end += s
+                    regional = false
+                    continue
+                case next == 0x200D:
+                    if after, as := utf8.DecodeRuneInString(text[end+s:]); isEmoji(after) {
+                        end += s + as
+                        continue
+                    }
+                }
+                break
+            }
+            tt = TokenEmoji
+        case isWordRune(r):
+            for end < len(text) {
+                next, s := utf8.DecodeRuneInString(text[end:])
+                if !isWordRune(next) {
+                    break
+                }
+                end += s
+            }
+        default:
+            i, prev = end, r
+            continue
+        }
+
+        tokens = append(tokens, Token{Text: text[i:end], Start: i, End: end, Type: tt})
+        prev, _ = utf8.DecodeLastRuneInString(text[i:end])
+        i = end
+    }
+    return tokens
+}
+
+// SocialNormalizer is a token filter for informal brazilian portuguese.
+// Laughter is typed as TokenLaughter, elongated letters are squeezed,
+// abbreviations are expanded and hashtags are segmented in words.
+type SocialNormalizer struct {
+    abbreviations map[string][]string // Words of each abbreviation
+    lexicon       map[string]bool     // Known words for hashtags and elongations
+    Laughter      string              // Text of laughter tokens
+}
+
+// Create a normalizer using a copy of the given abbreviation table. If
+// table is nil, DefaultAbbreviations is used. Stop words and expansions
+// of abbreviations are known words for hashtag segmentation.
+func NewSocialNormalizer(table map[string][]string) *SocialNormalizer {
+    if table == nil {
+        table = DefaultAbbreviations
+    }
+
+    n := new(SocialNormalizer)
+    n.abbreviations = make(map[string][]string, len(table))
+    n.lexicon = make(map[string]bool)
+    n.Laughter = "kkk"
+    for k, v := range table {
+        n.Add(k, v...)
+    }
+    n.AddWords(PortugueseStopwords...)
+    return n
+}
+
+// Add an abbreviation to the normalizer table, replacing any previous
+// expansion. Returns the normalizer to allow chained calls.
+func (n *SocialNormalizer) Add(abbreviation string, words ...string) *SocialNormalizer {
+    n.abbreviations[strings.ToLower(abbreviation)] = words
+    n.AddWords(words...)
+    return n
+}
+
+// Remove an abbreviation from the normalizer table.
+func (n *SocialNormalizer) Remove(abbreviation string) {
+    delete(n.abbreviations, strings.ToLower(abbreviation))
+}
+
+// Expand returns the words an abbreviation stands for. If the word is
+// not a known abbreviation, nil is returned. Lookup is case insensitive.
+func (n *SocialNormalizer) Expand(word string) []string {
+    return n.abbreviations[strings.ToLower(word)]
+}
+
+// AddWords adds known words used to segment lowercase hashtags and to
+// keep doubled o and e when squeezing elongations, as in "coordenar".
+func (n *SocialNormalizer) AddWords(words ...string) *SocialNormalizer {
+    for _, w := range words {
+        n.lexicon[strings.ToLower(w)] = true
+    }
+    return n
+}
+
+// Split a hashtag body at case changes and digits, as in "FicaDica" or
+// "BBB24". Returns nil if the body has no case change.
+func splitCamelCase(body string) []string {
+    runes := []rune(body)
+    kind := func(r rune) int {
+        switch {
+        case unicode.IsDigit(r):
+            return 0
+        case unicode.IsUpper(r):
+            return 1
+        case r == '_':
+            return 3
+        }
+        return 2
+    }
+
+    res := make([]string, 0)
+    start := 0
+    for i := 1; i <= len(runes); i++ {
+        split := i == len(runes)
+        if !split {
+            prev, cur := kind(runes[i-1]), kind(runes[i])
+            split = cur == 3 || prev == 3 ||
+                prev == 0 && cur != 0 || prev != 0 && cur == 0 ||
+                prev == 2 && cur == 1 ||
+                prev == 1 && cur == 1 && i+1 < len(runes) && kind(runes[i+1]) == 2
+        }
+        if split {
+            if part := strings.Trim(string(runes[start:i]), "_"); part != "" {
+                res = append(res, part)
+            }
+            start = i
+        }
+    }
+
+    if len(res) < 2 {
+        return nil
+    }
+    return res
+}
+
+// Segment a lowercase hashtag body in known words, with the fewest
+// words. Returns nil if the body can not be segmented.
+func (n *SocialNormalizer) segmentWords(body string) []string {
+    runes := []rune(body)
+    best := make([][]string, len(runes)+1)
+    best[0] = []string{}
+    for end := 1; end <= len(runes); end++ {
+        for start := 0; start < end; start++ {
+            if best[start] == nil {
+                continue
+            }
+            word := string(runes[start:end])
+            if !n.lexicon[word] {
+                continue
+            }
+            if best[end] == nil || len(best[start])+1 < len(best[end]) {
+                best[end] = append(append([]string{}, best[start]...), word)
+            }
+        }
+    }
+
+    if len(best[len(runes)]) < 2 {
+        return nil
+    }
+    return best[len(runes)]
+}
+
+// SegmentHashtag returns the words of a hashtag, with or without the
+// leading '#'. Mixed case hashtags are split at case changes, lowercase
+// ones by the known words. Hashtags that can not be segmented are
+// returned as a single word.
+func (n *SocialNormalizer) SegmentHashtag(tag string) []string {
+    body := strings.TrimPrefix(tag, "#")
+    if words := splitCamelCase(body); words != nil {
+        return words
+    }
+    if words := n.segmentWords(strings.ToLower(body)); words != nil {
+        return words
+    }
+    return []string{strings.Trim(body, "_")}
+}
+
+// Maximum number of elongated runs of o and e tried doubled against the
+// lexicon.
+const maxDoubledRuns = 4
+
+// Squeeze the elongations of a word. Elongated o and e are squeezed to a
+// single letter, unless doubling some of them gives a known word.
+func (n *SocialNormalizer) squeeze(word string) string {
+    runs := 0
+    res := squeezeElongations(word, func(run int) bool {
+        runs++
+        return false
+    })
+    if runs == 0 || runs > maxDoubledRuns || n.lexicon[strings.ToLower(res)] {
+        return res
+    }
+
+    for mask := 1; mask < 1<<runs; mask++ {
+        w := squeezeElongations(word, func(run int) bool {
+            return mask&(1<<run) != 0
+        })
+        if n.lexicon[strings.ToLower(w)] {
+            return w
+        }
+    }
+    return res
+}
+
+// Filter normalizes the token stream. Words produced by hashtags and
+// abbreviations keep the offsets of the original token.
+func (n *SocialNormalizer) Filter(tokens []Token) []Token {
+    res := make([]Token, 0, len(tokens))
+    for _, t := range tokens {
+        switch t.Type {
+        case TokenHashtag:
+            for _, w := range n.SegmentHashtag(t.Text) {
+                res = append(res, Token{Text: w, Start: t.Start, End: t.End})
+            }
+        case TokenWord:
+            if IsLaughter(t.Text) {
+                t.Text = n.Laughter
+                t.Type = TokenLaughter
+                res = append(res, t)
+                continue
+            }
+            t.Text = n.squeeze(t.Text)
+            words := n.Expand(t.Text)
+            if words == nil {
+                res = append(res, t)
+                continue
+            }
+            for _, w := range words {
+                res = append(res, Token{Text: w, Start: t.Start, End: t.End})
+            }
+        default:
+            res = append(res, t)
+        }
+    }
+    return res
+}
+
+// Create an analyzer for social media text. Text is split by
+// TokenizeSocial and normalized before the filters of NewAnalyzer.
+func NewSocialAnalyzer(stemmer Stemmer) *Analyzer {
+    a := NewAnalyzer(stemmer)
+    a.Tokenizer = TokenizeSocial
+    a.Filters = append([]TokenFilter{NewSocialNormalizer(nil)}, a.Filters...)
+    return a
+}
@@ -0,0 +1,184 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "reflect"
+    "testing"
+)
+
+// TestTokenizeSocial checks if entities are found with their types and
+// offsets.
+func TestTokenizeSocial(t *testing.T) {
+    text := "Oi @joao_silva, veja https://t.co/abc). #FicaDica 👍🏽👨‍👩‍👧 🇧🇷 a@b.com"
+    expected := []Token{
+        {"Oi", 0, 2, TokenWord},
+        {"@joao_silva", 3, 14, TokenMention},
+        {"veja", 16, 20, TokenWord},
+        {"https://t.co/abc", 21, 37, TokenURL},
+        {"#FicaDica", 40, 49, TokenHashtag},
+        {"👍🏽", 50, 58, TokenEmoji},
+        {"👨‍👩‍👧", 58, 76, TokenEmoji},
+        {"🇧🇷", 77, 85, TokenEmoji},
+        {"a", 86, 87, TokenWord},
+        {"b", 88, 89, TokenWord},
+        {"com", 90, 93, TokenWord},
+    }
+
+    tokens := TokenizeSocial(text)
+    if !reflect.DeepEqual(tokens, expected) {
+        t.Errorf("Wrong tokens.\nexpected= %v\nactual= %v\n", expected, tokens)
+    }
+    for _, tk := range tokens {
+        if text[tk.Start:tk.End] != tk.Text {
+            t.Errorf("Offsets do not match text. token= %v\n", tk)
+        }
+    }
+}
+
+// TestSqueezeElongations checks if repeated letters are shortened.
+func TestSqueezeElongations(t *testing.T) {
+    tests := []struct {
+        word, expected string
+    }{
+        {"muuuuito", "muito"},
+        {"BOOOOA", "BOA"},
+        {"lindaaaa", "linda"},
+        {"vooooou", "vou"},
+        {"booooom", "bom"},
+        {"beeeem", "bem"},
+        {"noooossa", "nossa"},
+        {"carrrrro", "carro"},
+        {"passssso", "passo"},
+        {"cooperar", "cooperar"},
+        {"leem", "leem"},
+        {"1000", "1000"},
+        {"2000000", "2000000"},
+        {"", ""},
+    }
+
+    for _, test := range tests {
+        if res := SqueezeElongations(test.word); res != test.expected {
+            t.Errorf("Wrong word. expected= %s actual= %s\n", test.expected, res)
+        }
+    }
+}
+
+// TestIsLaughter checks common written laughter.
+func TestIsLaughter(t *testing.T) {
+    for _, w := range []string{"kkk", "KKKKKK", "rsrs", "rsrsr", "haha", "HAHAHA", "hehe", "huehue", "ksks"} {
+        if !IsLaughter(w) {
+            t.Errorf("%s should be laughter\n", w)
+        }
+    }
+    for _, w := range []string{"kk", "rs", "ha", "casa", "hahaxd"} {
+        if IsLaughter(w) {
+            t.Errorf("%s should not be laughter\n", w)
+        }
+    }
+}
+
+// TestSegmentHashtag checks camel case and dictionary segmentation.
+func TestSegmentHashtag(t *testing.T) {
+    n := NewSocialNormalizer(nil).AddWords("fica", "dica", "vem", "pra", "rua")
+    tests := []struct {
+        tag      string
+        expected []string
+    }{
+        {"#FicaDica", []string{"Fica", "Dica"}},
+        {"#BBB24", []string{"BBB", "24"}},
+        {"#HTMLParser", []string{"HTML", "Parser"}},
+        {"#fica_dica", []string{"fica", "dica"}},
+        {"#vemprarua", []string{"vem", "pra", "rua"}},
+        {"#ficadica", []string{"fica", "dica"}},
+        {"#xyzw", []string{"xyzw"}},
+    }
+
+    for _, test := range tests {
+        if words := n.SegmentHashtag(test.tag); !reflect.DeepEqual(words, test.expected) {
+            t.Errorf("Wrong words of %s. expected= %v actual= %v\n", test.tag, test.expected, words)
+        }
+    }
+}
+
+// TestSocialNormalizer checks the normalized token stream.
+func TestSocialNormalizer(t *testing.T) {
+    n := NewSocialNormalizer(nil).Add("sqn", "só", "que", "não")
+    text := "Muuuito bom vc viu kkkkk sqn #FicaDica 😂"
+    expected := []Token{
+        {"Muito", 0, 7, TokenWord},
+        {"bom", 8, 11, TokenWord},
+        {"você", 12, 14, TokenWord},
+        {"viu", 15, 18, TokenWord},
+        {"kkk", 19, 24, TokenLaughter},
+        {"só", 25, 28, TokenWord},
+        {"que", 25, 28, TokenWord},
+        {"não", 25, 28, TokenWord},
+        {"Fica", 29, 38, TokenWord},
+        {"Dica", 29, 38, TokenWord},
+        {"😂", 39, 43, TokenEmoji},
+    }
+
+    if tokens := n.Filter(TokenizeSocial(text)); !reflect.DeepEqual(tokens, expected) {
+        t.Errorf("Wrong tokens.\nexpected= %v\nactual= %v\n", expected, tokens)
+    }
+
+    n.AddWords("coordenar", "leem")
+    squeezed := []struct {
+        word, expected string
+    }{
+        {"cooordenar", "coordenar"},
+        {"leeeem", "leem"},
+        {"vooooou", "vou"},
+        {"BOOOOA", "BOA"},
+    }
+    for _, test := range squeezed {
+        if res := n.squeeze(test.word); res != test.expected {
+            t.Errorf("Wrong squeeze. expected= %s actual= %s\n", test.expected, res)
+        }
+    }
+
+    n.Remove("vc")
+    if n.Expand("VC") != nil || n.Expand("Tbm")[0] != "também" {
+        t.Errorf("Wrong abbreviation table\n")
+    }
+}
+
+// TestSocialAnalyzer checks if entities are kept unstemmed.
+func TestSocialAnalyzer(t *testing.T) {
+    a := NewSocialAnalyzer(NewPorterStemmer())
+    terms := a.Terms("Amei as ofertaaaas de hj!!! rsrs @Loja #Promoções")
+    expected := []string{"ame", "ofert", "hoj", "kkk", "@Loja", "promoçõ"}
+    if !reflect.DeepEqual(terms, expected) {
+        t.Errorf("Wrong terms. expected= %v actual= %v\n", expected, terms)
+    }
+
+    terms = a.Terms("R$ 1000 em 2000")
+    if !reflect.DeepEqual(terms, []string{"r", "1000", "2000"}) {
+        t.Errorf("Numbers should not be squeezed: %v\n", terms)
+    }
+}
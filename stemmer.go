@@ -32,3 +32,10 @@ package ptstemmer
 type Stemmer interface {
     Stem(word string) string
 }
+
+// Phonetic encoders should implement the PhoneticEncoder interface.
+// An encoder should return the same key for words that sound alike, so
+// that spelling variants of a name can be matched.
+type PhoneticEncoder interface {
+    Encode(word string) string
+}
@@ -0,0 +1,101 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+)
+
+// PortugueseStopwords is the snowball list of portuguese stop words.
+var PortugueseStopwords = []string{
+    "de", "a", "o", "que", "e", "do", "da", "em", "um", "para", "com",
+    "não", "uma", "os", "no", "se", "na", "por", "mais", "as", "dos",
+    "como", "mas", "ao", "ele", "das", "à", "seu", "sua", "ou", "quando",
+    "muito", "nos", "já", "eu", "também", "só", "pelo", "pela", "até",
+    "isso", "ela", "entre", "depois", "sem", "mesmo", "aos", "seus",
+    "quem", "nas", "me", "esse", "eles", "você", "essa", "num", "nem",
+    "suas", "meu", "às", "minha", "numa", "pelos", "elas", "qual", "nós",
+    "lhe", "deles", "essas", "esses", "pelas", "este", "dele", "tu",
+    "te", "vocês", "vos", "lhes", "meus", "minhas", "teu", "tua", "teus",
+    "tuas", "nosso", "nossa", "nossos", "nossas", "dela", "delas", "esta",
+    "estes", "estas", "aquele", "aquela", "aqueles", "aquelas", "isto",
+    "aquilo", "estou", "está", "estamos", "estão", "estive", "esteve",
+    "estivemos", "estiveram", "estava", "estávamos", "estavam",
+    "estivera", "estivéramos", "esteja", "estejamos", "estejam",
+    "estivesse", "estivéssemos", "estivessem", "estiver", "estivermos",
+    "estiverem", "hei", "há", "havemos", "hão", "houve", "houvemos",
+    "houveram", "houvera", "houvéramos", "haja", "hajamos", "hajam",
+    "houvesse", "houvéssemos", "houvessem", "houver", "houvermos",
+    "houverem", "houverei", "houverá", "houveremos", "houverão",
+    "houveria", "houveríamos", "houveriam", "sou", "somos", "são", "era",
+    "éramos", "eram", "fui", "foi", "fomos", "foram", "fora", "fôramos",
+    "seja", "sejamos", "sejam", "fosse", "fôssemos", "fossem", "for",
+    "formos", "forem", "serei", "será", "seremos", "serão", "seria",
+    "seríamos", "seriam", "tenho", "tem", "temos", "tém", "tinha",
+    "tínhamos", "tinham", "tive", "teve", "tivemos", "tiveram", "tivera",
+    "tivéramos", "tenha", "tenhamos", "tenham", "tivesse", "tivéssemos",
+    "tivessem", "tiver", "tivermos", "tiverem", "terei", "terá",
+    "teremos", "terão", "teria", "teríamos", "teriam",
+}
+
+// StopwordFilter removes stop words from a token stream. Tokens are
+// compared in lower case.
+type StopwordFilter struct {
+    words map[string]bool // Stop words in lower case
+}
+
+// Create a stop word filter. If words is nil, PortugueseStopwords is
+// used.
+func NewStopwordFilter(words []string) *StopwordFilter {
+    if words == nil {
+        words = PortugueseStopwords
+    }
+
+    f := new(StopwordFilter)
+    f.words = make(map[string]bool, len(words))
+    for _, w := range words {
+        f.words[strings.ToLower(w)] = true
+    }
+    return f
+}
+
+// IsStopword returns true if the word is a stop word.
+func (f *StopwordFilter) IsStopword(word string) bool {
+    return f.words[strings.ToLower(word)]
+}
+
+// Filter returns the tokens that are not stop words. Only word tokens
+// are removed.
+func (f *StopwordFilter) Filter(tokens []Token) []Token {
+    res := make([]Token, 0, len(tokens))
+    for _, t := range tokens {
+        if t.Type != TokenWord || !f.IsStopword(t.Text) {
+            res = append(res, t)
+        }
+    }
+    return res
+}
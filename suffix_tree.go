@@ -27,114 +27,83 @@
 package ptstemmer
 
 import (
-    "unicode/utf8"
+    "github.com/tncardoso/ptstemmer/trie"
 )
 
-// A node in the suffix tree. It stores the children of this node along
-// with the word, if existent, that finishes in this node.
-type node struct {
-    children map[rune]*node // Edges leaving this node.
-    word     string         // Word completed in this node.
-    group    int            // Group of this word
-}
-
 // A suffix tree used to identify the longest known suffix in a given
 // word. Along with each suffix, an identifier is stored. This
 // identifier is used to choose which action should be taken in the
 // stemming process. 
 type suffixTree struct {
-    root *node // Root node of suffix tree
-}
-
-// Create a new tree node with default values.
-func newNode() *node {
-    n := new(node)
-    n.children = make(map[rune]*node)
-    n.word = ""
-    n.group = -1
-    return n
+    trie *trie.SuffixTrie[int] // Suffixes and their groups
 }
 
-// Create a new suffix tree with the root node.
+// Create a new empty suffix tree.
 func newSuffixTree() *suffixTree {
     t := new(suffixTree)
-    t.root = newNode()
+    t.trie = trie.NewSuffixTrie[int]()
     return t
 }
 
-// Add a new suffix to the tree. The word is inserted in reverse order
-// to make it easier to match suffixes. The group value is used to
-// identify the category of the suffix and take the necessary actions.
+// Add a new suffix to the tree. The group value is used to identify the
+// category of the suffix and take the necessary actions.
 func (st *suffixTree) Add(word string, group int) *suffixTree {
-    cnode := st.root
-    runes := []rune(word)
-
-    for i := len(runes) - 1; i >= 0; i-- {
-        n, ok := cnode.children[runes[i]]
-        if ok {
-            cnode = n
-        } else {
-            t := newNode()
-            cnode.children[runes[i]] = t
-            cnode = t
-        }
-    }
-
-    cnode.word = word
-    cnode.group = group
+    st.trie.Add(word, group)
     return st
 }
 
 // Returns true if a given word is already stored in the suffix tree.
 func (st *suffixTree) Contains(word string) bool {
-    cnode := st.root
-    runes := []rune(word)
-
-    for i := len(runes) - 1; i >= 0; i-- {
-        n, ok := cnode.children[runes[i]]
-        if ok {
-            cnode = n
-        } else {
-            return false
-        }
-    }
-
-    if cnode.word != "" && cnode.word == word {
-        return true
-    }
-
-    return false
+    return st.trie.Contains(word)
 }
 
 // Returns the longest known suffix that matches the given word. If no
 // suffix is found, empty string "" and group id -1 are returned. If a known
 // suffix matches the word, it is returned along with its category id.
 func (st *suffixTree) LongestSuffix(word string) (string, int) {
-    cnode := st.root
-    runes := []rune(word)
+    suffix, group, ok := st.trie.LongestMatch(word)
+    if !ok {
+        return "", -1
+    }
+    return suffix, group
+}
+
+// Write the suffixes of the tree and their groups.
+func (st *suffixTree) encode(w *binaryWriter) {
+    w.putUvarint(uint64(st.trie.Len()))
+    st.trie.Walk(func(suffix string, group int) bool {
+        w.putString(suffix)
+        w.putVarint(int64(group))
+        return true
+    })
+}
 
-    currentSuffix := ""
-    currentSuffixSize := -1
-    currentSuffixGroup := -1
+// Add the suffixes written by encode to the tree.
+func (st *suffixTree) decode(r *binaryReader) {
+    n := r.uvarint()
+    for i := uint64(0); i < n && r.err == nil; i++ {
+        suffix := r.string()
+        group := r.varint()
+        st.Add(suffix, int(group))
+    }
+}
 
-    for i := len(runes) - 1; i >= 0; i-- {
-        n, ok := cnode.children[runes[i]]
-        if ok {
-            cnode = n
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (st *suffixTree) MarshalBinary() ([]byte, error) {
+    w := newBinaryWriter(kindSuffixTree)
+    st.encode(w)
+    return w.bytes(), nil
+}
 
-            // check if a word finishes in this node
-            if cnode.word != "" {
-                sz := utf8.RuneCountInString(cnode.word)
-                if sz > currentSuffixSize {
-                    currentSuffix = cnode.word
-                    currentSuffixSize = sz
-                    currentSuffixGroup = cnode.group
-                }
-            }
-        } else {
-            break
-        }
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// Suffixes previously stored in the tree are discarded.
+func (st *suffixTree) UnmarshalBinary(data []byte) error {
+    r, err := newBinaryReader(data, kindSuffixTree)
+    if err != nil {
+        return err
     }
 
-    return currentSuffix, currentSuffixGroup
+    st.trie = trie.NewSuffixTrie[int]()
+    st.decode(r)
+    return r.close()
 }
@@ -27,6 +27,8 @@
 package ptstemmer
 
 import (
+    "encoding/binary"
+    "hash/crc32"
     "testing"
 )
 
@@ -128,3 +130,54 @@ func TestLongestSuffix(t *testing.T) {
         }
     }
 }
+
+// Checks if a suffix tree survives a binary round trip and if corrupted
+// data is rejected.
+func TestSuffixTreeBinary(t *testing.T) {
+    st := newSuffixTree()
+    st.Add("mente", 5).Add("aça~o", 0).Add("idade", 6)
+
+    data, err := st.MarshalBinary()
+    if err != nil {
+        t.Fatalf("Could not marshal tree: %v\n", err)
+    }
+
+    res := newSuffixTree()
+    res.Add("other", 1)
+    if err := res.UnmarshalBinary(data); err != nil {
+        t.Fatalf("Could not unmarshal tree: %v\n", err)
+    }
+    if res.Contains("other") {
+        t.Errorf("Previous suffix was kept: other\n")
+    }
+    for _, w := range []string{"felizmente", "naça~o", "cidade"} {
+        s1, g1 := st.LongestSuffix(w)
+        s2, g2 := res.LongestSuffix(w)
+        if s1 != s2 || g1 != g2 {
+            t.Errorf("Wrong suffix. word= %s expected= %s %d actual= %s %d\n",
+                w, s1, g1, s2, g2)
+        }
+    }
+
+    data[len(data)/2] ^= 0xff
+    if err := res.UnmarshalBinary(data); err != ErrChecksum {
+        t.Errorf("Corrupted data accepted. err= %v\n", err)
+    }
+    if err := res.UnmarshalBinary(data[:3]); err != ErrInvalidData {
+        t.Errorf("Truncated data accepted. err= %v\n", err)
+    }
+
+    ps := NewPorterStemmer()
+    data, _ = ps.MarshalBinary()
+    if err := res.UnmarshalBinary(data); err != ErrKind {
+        t.Errorf("Wrong kind of data accepted. err= %v\n", err)
+    }
+
+    data, _ = st.MarshalBinary()
+    data[len(binaryMagic)] = binaryVersion + 1
+    body := data[:len(data)-4]
+    binary.BigEndian.PutUint32(data[len(body):], crc32.ChecksumIEEE(body))
+    if err := res.UnmarshalBinary(data); err != ErrVersion {
+        t.Errorf("Unsupported version accepted. err= %v\n", err)
+    }
+}
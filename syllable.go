@@ -0,0 +1,230 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode"
+)
+
+// Runes read as vowels when splitting syllables.
+const syllableVowels = "aeiouyáéíóúâêôãõàü"
+
+// Vowels with an accent marking the stressed syllable. Tildes mark the
+// stress only if no other accent is present.
+const (
+    stressAccents = "áéíóúâêô"
+    nasalAccents  = "ãõ"
+)
+
+// Endings of words stressed on the penultimate syllable when they have
+// no accent. Other words are stressed on the last syllable.
+var paroxytoneEndings = []string{
+    "a", "e", "o", "as", "es", "os", "am", "em", "ens",
+}
+
+// Syllables is a word split into syllables.
+type Syllables struct {
+    Parts  []string // Syllables, in order
+    Stress int      // Index of the stressed syllable, -1 if there is none
+}
+
+// String returns the syllables separated by hyphens.
+func (s Syllables) String() string {
+    return s.Hyphenate("-")
+}
+
+// Hyphenate returns the syllables separated by sep.
+func (s Syllables) Hyphenate(sep string) string {
+    return strings.Join(s.Parts, sep)
+}
+
+// syllableUnit is a vowel or a group of letters read as one consonant,
+// such as a digraph.
+type syllableUnit struct {
+    start int  // Index of the first rune
+    end   int  // Index after the last rune
+    vowel bool // True if the unit is a vowel
+    text  string
+}
+
+// Split lowercase runes in units. The digraphs lh, nh and ch, and qu and
+// gu before a vowel, are single consonants.
+func syllableUnits(runes []rune) []syllableUnit {
+    isVowel := func(i int) bool {
+        return i < len(runes) && strings.ContainsRune(syllableVowels, runes[i])
+    }
+
+    units := make([]syllableUnit, 0, len(runes))
+    for i := 0; i < len(runes); {
+        size := 1
+        if i+1 < len(runes) {
+            switch r, next := runes[i], runes[i+1]; {
+            case next == 'h' && (r == 'l' || r == 'n' || r == 'c'):
+                size = 2
+            case (r == 'q' || r == 'g') && (next == 'u' || next == 'ü') && isVowel(i+2):
+                size = 2
+            }
+        }
+        units = append(units, syllableUnit{
+            start: i,
+            end:   i + size,
+            vowel: size == 1 && isVowel(i),
+            text:  string(runes[i : i+size]),
+        })
+        i += size
+    }
+    return units
+}
+
+// Return true if two consonants begin a syllable together, as in "pr"
+// or "bl".
+func isOnsetCluster(first, second syllableUnit) bool {
+    if len(first.text) != 1 || len(second.text) != 1 {
+        return false
+    }
+    if second.text != "l" && second.text != "r" {
+        return false
+    }
+    if first.text == "t" && second.text == "l" || first.text == "d" && second.text == "l" {
+        return false
+    }
+    return strings.Contains("pbtdcgfvk", first.text)
+}
+
+// Return true if two adjacent vowels form a diphthong. The units after
+// them decide if an i or u closes the syllable, as in "sa-ir" and
+// "ra-i-nha", which makes it a hiatus.
+func isDiphthong(v1, v2 string, after []syllableUnit) bool {
+    if strings.Contains(nasalAccents, v1) {
+        return v2 == "o" || v2 == "e" || v2 == "i"
+    }
+    if v2 != "i" && v2 != "u" || v1 == v2 {
+        return false
+    }
+
+    if len(after) > 0 && !after[0].vowel {
+        if after[0].text == "nh" {
+            return false
+        }
+        closes := len(after) == 1 || !after[1].vowel
+        if closes && strings.Contains("lmnrz", after[0].text) {
+            return false
+        }
+    }
+    return true
+}
+
+// Syllabify splits a word in syllables and finds its stressed syllable.
+// Diphthongs are kept together and hiatus split, digraphs such as lh,
+// nh, ch, qu and gu stay in the same syllable, while rr, ss, sc and xc
+// are split. Words without accents are stressed on the penultimate
+// syllable if they end in a, e, o, am, em or ens, optionally followed by
+// s, and on the last one otherwise.
+func Syllabify(word string) Syllables {
+    original := []rune(word)
+    lower := make([]rune, len(original))
+    for i, r := range original {
+        lower[i] = unicode.ToLower(r)
+    }
+    units := syllableUnits(lower)
+
+    // Group units in nuclei of one or two vowels.
+    type nucleus struct{ first, last int }
+    nuclei := make([]nucleus, 0, len(units))
+    for i := 0; i < len(units); i++ {
+        if !units[i].vowel {
+            continue
+        }
+        n := nucleus{i, i}
+        if i+1 < len(units) && units[i+1].vowel &&
+            isDiphthong(units[i].text, units[i+1].text, units[i+2:]) {
+            n.last = i + 1
+        }
+        nuclei = append(nuclei, n)
+        i = n.last
+    }
+
+    if len(nuclei) == 0 {
+        if len(original) == 0 {
+            return Syllables{Parts: []string{}, Stress: -1}
+        }
+        return Syllables{Parts: []string{word}, Stress: 0}
+    }
+
+    // Place a boundary in the consonants between each pair of nuclei.
+    bounds := make([]int, 0, len(nuclei)+1)
+    bounds = append(bounds, 0)
+    for i := 1; i < len(nuclei); i++ {
+        consonants := units[nuclei[i-1].last+1 : nuclei[i].first]
+        split := len(consonants) - 1
+        switch {
+        case len(consonants) == 0:
+            split = 0
+        case len(consonants) >= 2 && isOnsetCluster(consonants[len(consonants)-2], consonants[len(consonants)-1]):
+            split = len(consonants) - 2
+        }
+
+        if split < len(consonants) {
+            bounds = append(bounds, consonants[split].start)
+        } else {
+            bounds = append(bounds, units[nuclei[i].first].start)
+        }
+    }
+    bounds = append(bounds, len(original))
+
+    res := Syllables{Parts: make([]string, 0, len(nuclei))}
+    for i := 1; i < len(bounds); i++ {
+        res.Parts = append(res.Parts, string(original[bounds[i-1]:bounds[i]]))
+    }
+    res.Stress = stressedSyllable(lower, bounds)
+    return res
+}
+
+// Return the index of the stressed syllable of a lowercase word, given
+// the rune index where each syllable starts.
+func stressedSyllable(lower []rune, bounds []int) int {
+    count := len(bounds) - 1
+    for _, accents := range []string{stressAccents, nasalAccents} {
+        for i := 0; i < count; i++ {
+            if strings.ContainsAny(string(lower[bounds[i]:bounds[i+1]]), accents) {
+                return i
+            }
+        }
+    }
+
+    if count == 1 {
+        return 0
+    }
+    word := string(lower)
+    for _, ending := range paroxytoneEndings {
+        if strings.HasSuffix(word, ending) {
+            return count - 2
+        }
+    }
+    return count - 1
+}
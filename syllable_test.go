@@ -0,0 +1,86 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestSyllabify checks syllable splitting and stress of words covering
+// digraphs, consonant clusters, diphthongs and hiatus.
+func TestSyllabify(t *testing.T) {
+    tests := []struct {
+        word     string
+        expected string
+        stress   int
+    }{
+        {"casa", "ca-sa", 0},
+        {"carro", "car-ro", 0},
+        {"pássaro", "pás-sa-ro", 0},
+        {"nascer", "nas-cer", 1},
+        {"desça", "des-ça", 0},
+        {"exceto", "ex-ce-to", 1},
+        {"filho", "fi-lho", 0},
+        {"ninho", "ni-nho", 0},
+        {"chave", "cha-ve", 0},
+        {"guerra", "guer-ra", 0},
+        {"água", "á-gua", 0},
+        {"Uruguai", "U-ru-guai", 2},
+        {"saída", "sa-í-da", 1},
+        {"juiz", "ju-iz", 1},
+        {"rainha", "ra-i-nha", 1},
+        {"cuidado", "cui-da-do", 1},
+        {"ainda", "a-in-da", 1},
+        {"poeta", "po-e-ta", 1},
+        {"cooperar", "co-o-pe-rar", 3},
+        {"põem", "põem", 0},
+        {"órfão", "ór-fão", 0},
+        {"irmã", "ir-mã", 1},
+        {"homens", "ho-mens", 0},
+        {"abstrato", "abs-tra-to", 1},
+        {"perspectiva", "pers-pec-ti-va", 2},
+        {"atleta", "at-le-ta", 1},
+        {"psicologia", "psi-co-lo-gi-a", 3},
+        {"ideia", "i-dei-a", 1},
+        {"urubu", "u-ru-bu", 2},
+        {"CASA", "CA-SA", 0},
+        {"ps", "ps", 0},
+        {"", "", -1},
+    }
+
+    for _, test := range tests {
+        s := Syllabify(test.word)
+        if s.String() != test.expected || s.Stress != test.stress {
+            t.Errorf("Wrong syllables of %s. expected= %s/%d actual= %s/%d\n",
+                test.word, test.expected, test.stress, s, s.Stress)
+        }
+    }
+
+    if h := Syllabify("pássaro").Hyphenate("­"); h != "pás­sa­ro" {
+        t.Errorf("Wrong hyphenation: %q\n", h)
+    }
+}
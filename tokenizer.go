@@ -0,0 +1,130 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "strings"
+    "unicode"
+)
+
+// TokenType classifies tokens. Filters that transform words, such as
+// stemming, only act on TokenWord tokens.
+type TokenType int
+
+const (
+    TokenWord     TokenType = iota // Word or number
+    TokenHashtag                   // Hashtag, such as #FicaDica
+    TokenMention                   // User mention, such as @fulano
+    TokenURL                       // Web address
+    TokenEmoji                     // Emoji or pictographic symbol
+    TokenLaughter                  // Laughter, such as kkkk or rsrs
+)
+
+// Names of the token types.
+var tokenTypeNames = []string{"word", "hashtag", "mention", "url", "emoji", "laughter"}
+
+// String returns the name of the token type.
+func (tt TokenType) String() string {
+    if tt < 0 || int(tt) >= len(tokenTypeNames) {
+        return "unknown"
+    }
+    return tokenTypeNames[tt]
+}
+
+// Token is a word found in a text. Start and End are byte offsets of the
+// token in the original text, so that text[Start:End] is the original
+// word even after filters have modified Text.
+type Token struct {
+    Text  string    // Token text, possibly modified by filters
+    Start int       // Offset of the first byte in the original text
+    End   int       // Offset after the last byte in the original text
+    Type  TokenType // Kind of token, TokenWord for plain text
+}
+
+// TokenFilter transforms a token stream. Filters may modify, remove or
+// insert tokens, but tokens derived from a word should keep the offsets
+// of that word.
+type TokenFilter interface {
+    Filter(tokens []Token) []Token
+}
+
+// Return true if the rune can be part of a word.
+func isWordRune(r rune) bool {
+    return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// Tokenize splits a text in words. Every maximal sequence of letters and
+// digits is considered a word, everything else is treated as a separator.
+func Tokenize(text string) []Token {
+    tokens := make([]Token, 0)
+    start := -1
+
+    for i, r := range text {
+        if isWordRune(r) {
+            if start < 0 {
+                start = i
+            }
+        } else if start >= 0 {
+            tokens = append(tokens, Token{Text: text[start:i], Start: start, End: i})
+            start = -1
+        }
+    }
+
+    if start >= 0 {
+        tokens = append(tokens, Token{Text: text[start:], Start: start, End: len(text)})
+    }
+
+    return tokens
+}
+
+// LowercaseFilter converts every word token to lower case.
+type LowercaseFilter struct{}
+
+// Filter lowercases tokens in place and returns the same slice.
+func (f LowercaseFilter) Filter(tokens []Token) []Token {
+    for i := range tokens {
+        if tokens[i].Type == TokenWord {
+            tokens[i].Text = strings.ToLower(tokens[i].Text)
+        }
+    }
+    return tokens
+}
+
+// StemFilter replaces the text of each word token by its stem.
+type StemFilter struct {
+    Stemmer Stemmer // Stemmer applied to each token
+}
+
+// Filter stems tokens in place and returns the same slice.
+func (f StemFilter) Filter(tokens []Token) []Token {
+    for i := range tokens {
+        if tokens[i].Type == TokenWord {
+            tokens[i].Text = f.Stemmer.Stem(tokens[i].Text)
+        }
+    }
+    return tokens
+}
@@ -0,0 +1,79 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "testing"
+)
+
+// TestTokenize checks if words and their offsets are correctly
+// identified.
+func TestTokenize(t *testing.T) {
+    var cases = []struct {
+        text   string
+        tokens []Token
+    }{
+        {"", []Token{}},
+        {"  ,. ", []Token{}},
+        {"ajuda", []Token{{"ajuda", 0, 5, TokenWord}}},
+        {"Não, ajudou!", []Token{{"Não", 0, 4, TokenWord}, {"ajudou", 6, 12, TokenWord}}},
+        {"são 10 ações", []Token{{"são", 0, 4, TokenWord}, {"10", 5, 7, TokenWord}, {"ações", 8, 15, TokenWord}}},
+    }
+
+    for _, c := range cases {
+        tokens := Tokenize(c.text)
+        if len(tokens) != len(c.tokens) {
+            t.Errorf("Wrong number of tokens. text= %s expected= %v actual= %v\n",
+                c.text, c.tokens, tokens)
+            continue
+        }
+        for i := range tokens {
+            if tokens[i] != c.tokens[i] {
+                t.Errorf("Wrong token. text= %s expected= %v actual= %v\n",
+                    c.text, c.tokens[i], tokens[i])
+            }
+            if c.text[tokens[i].Start:tokens[i].End] != tokens[i].Text {
+                t.Errorf("Offsets do not match text. token= %v\n", tokens[i])
+            }
+        }
+    }
+}
+
+// TestStemFilter checks if filters can be chained to stem a text.
+func TestStemFilter(t *testing.T) {
+    tokens := Tokenize("Ajudou a Ajudar")
+    tokens = LowercaseFilter{}.Filter(tokens)
+    tokens = StemFilter{NewPorterStemmer()}.Filter(tokens)
+
+    expected := []string{"ajud", "a", "ajud"}
+    for i, tk := range tokens {
+        if tk.Text != expected[i] {
+            t.Errorf("Invalid stem. expected= %s actual= %s\n",
+                expected[i], tk.Text)
+        }
+    }
+}
@@ -0,0 +1,95 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package trie
+
+// PrefixTrie stores keys to find which of them are prefixes of a given
+// word. The zero value is not usable, tries should
+// be created with NewPrefixTrie.
+type PrefixTrie[V any] struct {
+    t trie[V] // Keys stored from the first to the last rune
+}
+
+// Create an empty prefix trie.
+func NewPrefixTrie[V any]() *PrefixTrie[V] {
+    return &PrefixTrie[V]{t: newTrie[V]()}
+}
+
+// Add a prefix along with its value, replacing the value if the prefix
+// is already present. Returns the trie to allow chained calls.
+func (pt *PrefixTrie[V]) Add(prefix string, value V) *PrefixTrie[V] {
+    pt.t.add(prefix, []rune(prefix), value)
+    return pt
+}
+
+// Remove a prefix. Returns true if the prefix was present.
+func (pt *PrefixTrie[V]) Remove(prefix string) bool {
+    return pt.t.remove([]rune(prefix))
+}
+
+// Get returns the value stored with the prefix and true, or the zero
+// value and false if the prefix is not present.
+func (pt *PrefixTrie[V]) Get(prefix string) (V, bool) {
+    return pt.t.get([]rune(prefix))
+}
+
+// Contains returns true if the prefix is stored in the trie.
+func (pt *PrefixTrie[V]) Contains(prefix string) bool {
+    _, ok := pt.Get(prefix)
+    return ok
+}
+
+// LongestMatch returns the longest stored prefix of word along with its
+// value. The last result is false if no stored prefix matches.
+func (pt *PrefixTrie[V]) LongestMatch(word string) (string, V, bool) {
+    return pt.t.longest([]rune(word))
+}
+
+// AllMatches returns every stored prefix of word, ordered from the
+// shortest to the longest.
+func (pt *PrefixTrie[V]) AllMatches(word string) []Match[V] {
+    return pt.t.matches([]rune(word))
+}
+
+// Walk calls fn for every stored prefix, in lexicographic order. Walking stops when fn returns false.
+func (pt *PrefixTrie[V]) Walk(fn func(prefix string, value V) bool) {
+    pt.t.walk(pt.t.root, fn)
+}
+
+// Keys returns the stored prefixes, in the same order used by Walk.
+func (pt *PrefixTrie[V]) Keys() []string {
+    keys := make([]string, 0, pt.t.size)
+    pt.Walk(func(key string, value V) bool {
+        keys = append(keys, key)
+        return true
+    })
+    return keys
+}
+
+// Len returns the number of stored prefixes.
+func (pt *PrefixTrie[V]) Len() int {
+    return pt.t.size
+}
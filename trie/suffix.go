@@ -0,0 +1,96 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package trie
+
+// SuffixTrie stores keys in reverse order to find which of them are
+// suffixes of a given word. The zero value is not usable, tries should
+// be created with NewSuffixTrie.
+type SuffixTrie[V any] struct {
+    t trie[V] // Keys stored from the last to the first rune
+}
+
+// Create an empty suffix trie.
+func NewSuffixTrie[V any]() *SuffixTrie[V] {
+    return &SuffixTrie[V]{t: newTrie[V]()}
+}
+
+// Add a suffix along with its value, replacing the value if the suffix
+// is already present. Returns the trie to allow chained calls.
+func (st *SuffixTrie[V]) Add(suffix string, value V) *SuffixTrie[V] {
+    st.t.add(suffix, reversed(suffix), value)
+    return st
+}
+
+// Remove a suffix. Returns true if the suffix was present.
+func (st *SuffixTrie[V]) Remove(suffix string) bool {
+    return st.t.remove(reversed(suffix))
+}
+
+// Get returns the value stored with the suffix and true, or the zero
+// value and false if the suffix is not present.
+func (st *SuffixTrie[V]) Get(suffix string) (V, bool) {
+    return st.t.get(reversed(suffix))
+}
+
+// Contains returns true if the suffix is stored in the trie.
+func (st *SuffixTrie[V]) Contains(suffix string) bool {
+    _, ok := st.Get(suffix)
+    return ok
+}
+
+// LongestMatch returns the longest stored suffix of word along with its
+// value. The last result is false if no stored suffix matches.
+func (st *SuffixTrie[V]) LongestMatch(word string) (string, V, bool) {
+    return st.t.longest(reversed(word))
+}
+
+// AllMatches returns every stored suffix of word, ordered from the
+// shortest to the longest.
+func (st *SuffixTrie[V]) AllMatches(word string) []Match[V] {
+    return st.t.matches(reversed(word))
+}
+
+// Walk calls fn for every stored suffix, in the lexicographic order of
+// the reversed suffixes. Walking stops when fn returns false.
+func (st *SuffixTrie[V]) Walk(fn func(suffix string, value V) bool) {
+    st.t.walk(st.t.root, fn)
+}
+
+// Keys returns the stored suffixes, in the same order used by Walk.
+func (st *SuffixTrie[V]) Keys() []string {
+    keys := make([]string, 0, st.t.size)
+    st.Walk(func(key string, value V) bool {
+        keys = append(keys, key)
+        return true
+    })
+    return keys
+}
+
+// Len returns the number of stored suffixes.
+func (st *SuffixTrie[V]) Len() int {
+    return st.t.size
+}
@@ -0,0 +1,230 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package trie provides generic affix tries. A SuffixTrie finds the
+// known suffixes of a word and a PrefixTrie its known prefixes. Each key
+// is stored along with a value of any type.
+package trie
+
+import (
+    "sort"
+)
+
+// Match is a key found in a word along with its stored value.
+type Match[V any] struct {
+    Key   string // Matched key
+    Value V      // Value stored with the key
+}
+
+// A node in the trie. It stores the children of this node along with
+// the key, if existent, that finishes in this node.
+type node[V any] struct {
+    children map[rune]*node[V] // Edges leaving this node.
+    key      string            // Key completed in this node.
+    value    V                 // Value of the key
+    terminal bool              // True if a key finishes in this node
+}
+
+// Create a new trie node with default values.
+func newNode[V any]() *node[V] {
+    n := new(node[V])
+    n.children = make(map[rune]*node[V])
+    return n
+}
+
+// trie is the structure shared by prefix and suffix tries. Keys are
+// stored as rune sequences, the direction in which a key is read is
+// chosen by the caller.
+type trie[V any] struct {
+    root *node[V] // Root node of the trie
+    size int      // Number of stored keys
+}
+
+// Create a new trie with the root node.
+func newTrie[V any]() trie[V] {
+    return trie[V]{root: newNode[V]()}
+}
+
+// Store key with the given runes path, replacing any previous value.
+func (t *trie[V]) add(key string, path []rune, value V) {
+    cnode := t.root
+    for _, r := range path {
+        n, ok := cnode.children[r]
+        if !ok {
+            n = newNode[V]()
+            cnode.children[r] = n
+        }
+        cnode = n
+    }
+
+    if !cnode.terminal {
+        t.size++
+    }
+    cnode.key = key
+    cnode.value = value
+    cnode.terminal = true
+}
+
+// Find the node at the end of path. Returns nil if the path does not
+// exist.
+func (t *trie[V]) find(path []rune) *node[V] {
+    cnode := t.root
+    for _, r := range path {
+        n, ok := cnode.children[r]
+        if !ok {
+            return nil
+        }
+        cnode = n
+    }
+    return cnode
+}
+
+// Return the value of the key with the given path.
+func (t *trie[V]) get(path []rune) (V, bool) {
+    n := t.find(path)
+    if n == nil || !n.terminal {
+        var zero V
+        return zero, false
+    }
+    return n.value, true
+}
+
+// Remove the key with the given path. Nodes left without keys are
+// pruned. Returns true if the key was present.
+func (t *trie[V]) remove(path []rune) bool {
+    nodes := make([]*node[V], 0, len(path)+1)
+    cnode := t.root
+    nodes = append(nodes, cnode)
+    for _, r := range path {
+        n, ok := cnode.children[r]
+        if !ok {
+            return false
+        }
+        cnode = n
+        nodes = append(nodes, cnode)
+    }
+
+    if !cnode.terminal {
+        return false
+    }
+
+    var zero V
+    cnode.terminal = false
+    cnode.key = ""
+    cnode.value = zero
+    t.size--
+
+    // Prune nodes that lead to no key.
+    for i := len(path) - 1; i >= 0; i-- {
+        n := nodes[i+1]
+        if n.terminal || len(n.children) > 0 {
+            break
+        }
+        delete(nodes[i].children, path[i])
+    }
+    return true
+}
+
+// Return every key found along the given path, ordered from the
+// shortest to the longest.
+func (t *trie[V]) matches(path []rune) []Match[V] {
+    res := make([]Match[V], 0)
+    cnode := t.root
+    if cnode.terminal {
+        res = append(res, Match[V]{cnode.key, cnode.value})
+    }
+
+    for _, r := range path {
+        n, ok := cnode.children[r]
+        if !ok {
+            break
+        }
+        cnode = n
+
+        // check if a key finishes in this node
+        if cnode.terminal {
+            res = append(res, Match[V]{cnode.key, cnode.value})
+        }
+    }
+    return res
+}
+
+// Return the longest key found along the given path.
+func (t *trie[V]) longest(path []rune) (string, V, bool) {
+    var (
+        key   string
+        value V
+        found bool
+    )
+
+    cnode := t.root
+    if cnode.terminal {
+        key, value, found = cnode.key, cnode.value, true
+    }
+
+    for _, r := range path {
+        n, ok := cnode.children[r]
+        if !ok {
+            break
+        }
+        cnode = n
+
+        if cnode.terminal {
+            key, value, found = cnode.key, cnode.value, true
+        }
+    }
+    return key, value, found
+}
+
+// Visit every key in depth first order, with the children of a node
+// sorted by rune. Stops when fn returns false.
+func (t *trie[V]) walk(n *node[V], fn func(key string, value V) bool) bool {
+    if n.terminal && !fn(n.key, n.value) {
+        return false
+    }
+
+    runes := make([]rune, 0, len(n.children))
+    for r := range n.children {
+        runes = append(runes, r)
+    }
+    sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+
+    for _, r := range runes {
+        if !t.walk(n.children[r], fn) {
+            return false
+        }
+    }
+    return true
+}
+
+// Return the runes of a string in reverse order.
+func reversed(s string) []rune {
+    runes := []rune(s)
+    for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+        runes[i], runes[j] = runes[j], runes[i]
+    }
+    return runes
+}
@@ -0,0 +1,143 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package trie
+
+import (
+    "testing"
+)
+
+// Checks if keys are added, replaced, retrieved and removed from a
+// suffix trie.
+func TestSuffixTrie(t *testing.T) {
+    st := NewSuffixTrie[string]()
+    st.Add("mente", "adv").Add("idade", "noun").Add("ável", "adj")
+    st.Add("mente", "adverb")
+
+    if st.Len() != 3 {
+        t.Errorf("Wrong length. expected= 3 actual= %d\n", st.Len())
+    }
+    if v, ok := st.Get("mente"); !ok || v != "adverb" {
+        t.Errorf("Wrong value. expected= adverb actual= %s\n", v)
+    }
+    if st.Contains("ente") {
+        t.Errorf("False positive key: ente\n")
+    }
+
+    if !st.Remove("mente") || st.Remove("mente") || st.Remove("ente") {
+        t.Errorf("Wrong removal result\n")
+    }
+    if st.Contains("mente") || st.Len() != 2 {
+        t.Errorf("Key was not removed: mente\n")
+    }
+    if _, _, ok := st.LongestMatch("felizmente"); ok {
+        t.Errorf("Removed key still matches: mente\n")
+    }
+}
+
+// Checks if suffixes matching a word are found in length order.
+func TestSuffixMatches(t *testing.T) {
+    st := NewSuffixTrie[int]()
+    st.Add("a", 1).Add("ia", 2).Add("aria", 3).Add("ção", 4)
+
+    var cases = []struct {
+        word    string
+        matches []string
+    }{
+        {"padaria", []string{"a", "ia", "aria"}},
+        {"alegria", []string{"a", "ia"}},
+        {"ação", []string{"ção"}},
+        {"mesas", []string{}},
+        {"", []string{}},
+    }
+
+    for _, c := range cases {
+        m := st.AllMatches(c.word)
+        if len(m) != len(c.matches) {
+            t.Errorf("Wrong matches. word= %s expected= %v actual= %v\n",
+                c.word, c.matches, m)
+            continue
+        }
+        for i := range m {
+            if m[i].Key != c.matches[i] {
+                t.Errorf("Wrong match. word= %s expected= %s actual= %s\n",
+                    c.word, c.matches[i], m[i].Key)
+            }
+        }
+
+        k, _, ok := st.LongestMatch(c.word)
+        if len(m) > 0 && (!ok || k != m[len(m)-1].Key) {
+            t.Errorf("Wrong longest match. word= %s actual= %s\n", c.word, k)
+        }
+    }
+}
+
+// Checks if prefixes matching a word are found.
+func TestPrefixTrie(t *testing.T) {
+    pt := NewPrefixTrie[bool]()
+    pt.Add("in", true).Add("inter", true).Add("des", false)
+
+    m := pt.AllMatches("internacional")
+    if len(m) != 2 || m[0].Key != "in" || m[1].Key != "inter" {
+        t.Errorf("Wrong matches. expected= [in inter] actual= %v\n", m)
+    }
+
+    k, v, ok := pt.LongestMatch("desfazer")
+    if !ok || k != "des" || v {
+        t.Errorf("Wrong longest match. expected= des actual= %s\n", k)
+    }
+    if _, _, ok := pt.LongestMatch("fazer"); ok {
+        t.Errorf("False positive match: fazer\n")
+    }
+}
+
+// Checks if walking visits every key in order and stops when asked.
+func TestWalk(t *testing.T) {
+    pt := NewPrefixTrie[int]()
+    pt.Add("b", 2).Add("a", 1).Add("ab", 3).Add("c", 4)
+
+    keys := pt.Keys()
+    expected := []string{"a", "ab", "b", "c"}
+    if len(keys) != len(expected) {
+        t.Fatalf("Wrong keys. expected= %v actual= %v\n", expected, keys)
+    }
+    for i := range keys {
+        if keys[i] != expected[i] {
+            t.Errorf("Wrong key order. expected= %v actual= %v\n",
+                expected, keys)
+            break
+        }
+    }
+
+    visited := 0
+    pt.Walk(func(k string, v int) bool {
+        visited++
+        return visited < 2
+    })
+    if visited != 2 {
+        t.Errorf("Walk did not stop. visited= %d\n", visited)
+    }
+}
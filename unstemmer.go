@@ -0,0 +1,210 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "sort"
+    "strconv"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer/internal/counting"
+)
+
+// UnstemStrategy selects which surface form represents a stem.
+type UnstemStrategy int
+
+const (
+    // MostFrequent picks the form seen most often, shorter forms win
+    // ties.
+    MostFrequent UnstemStrategy = iota
+    // Shortest picks the shortest form, more frequent forms win ties.
+    Shortest
+)
+
+// Unstemmer maps stems back to readable words. It is trained with the
+// words of a corpus, counting how often each surface form produced each
+// stem, and returns the best form of a stem according to Strategy.
+type Unstemmer struct {
+    stemmer  Stemmer                   // Stemmer that produces the stems
+    forms    map[string]map[string]int // Count of each form of a stem
+    Strategy UnstemStrategy            // Criteria used to choose a form
+}
+
+// Create an empty unstemmer for the stems produced by stemmer.
+func NewUnstemmer(stemmer Stemmer) *Unstemmer {
+    u := new(Unstemmer)
+    u.stemmer = stemmer
+    u.forms = make(map[string]map[string]int)
+    u.Strategy = MostFrequent
+    return u
+}
+
+// Add an occurrence of a word. Words are stored in lower case.
+func (u *Unstemmer) Add(word string) {
+    u.AddCount(word, 1)
+}
+
+// AddCount adds n occurrences of a word.
+func (u *Unstemmer) AddCount(word string, n int) {
+    word = strings.ToLower(word)
+    u.addForm(u.stemmer.Stem(word), word, n)
+}
+
+// Add n occurrences of a form to a stem.
+func (u *Unstemmer) addForm(stem, form string, n int) {
+    forms, ok := u.forms[stem]
+    if !ok {
+        forms = make(map[string]int)
+        u.forms[stem] = forms
+    }
+    forms[form] += n
+}
+
+// Train adds every word of a text. Words are found with Tokenize.
+func (u *Unstemmer) Train(r io.Reader) error {
+    br := bufio.NewReader(r)
+    for {
+        l, err := br.ReadString('\n')
+        for _, t := range Tokenize(l) {
+            u.Add(t.Text)
+        }
+        if err == io.EOF {
+            return nil
+        } else if err != nil {
+            return err
+        }
+    }
+}
+
+// Unstem returns the form that best represents the stem and true. If no
+// form of the stem was seen, the stem itself and false are returned.
+func (u *Unstemmer) Unstem(stem string) (string, bool) {
+    forms, ok := u.forms[stem]
+    if !ok || len(forms) == 0 {
+        return stem, false
+    }
+
+    best := ""
+    bestCount := 0
+    for f, c := range forms {
+        if best == "" || u.better(f, c, best, bestCount) {
+            best, bestCount = f, c
+        }
+    }
+    return best, true
+}
+
+// Return true if form a, seen ca times, should be preferred over form b,
+// seen cb times. Remaining ties are broken alphabetically, so results do
+// not depend on map order.
+func (u *Unstemmer) better(a string, ca int, b string, cb int) bool {
+    la, lb := len([]rune(a)), len([]rune(b))
+    if u.Strategy == Shortest {
+        if la != lb {
+            return la < lb
+        }
+        if ca != cb {
+            return ca > cb
+        }
+    } else {
+        if ca != cb {
+            return ca > cb
+        }
+        if la != lb {
+            return la < lb
+        }
+    }
+    return a < b
+}
+
+// Forms returns the count of each form seen for a stem.
+func (u *Unstemmer) Forms(stem string) map[string]int {
+    res := make(map[string]int, len(u.forms[stem]))
+    for f, c := range u.forms[stem] {
+        res[f] = c
+    }
+    return res
+}
+
+// Len returns the number of known stems.
+func (u *Unstemmer) Len() int {
+    return len(u.forms)
+}
+
+// WriteTo implements the io.WriterTo interface. Counts are written one
+// per line, sorted by stem and form, in the format
+//
+//      [stem] TAB [form] TAB [count]
+func (u *Unstemmer) WriteTo(w io.Writer) (int64, error) {
+    stems := make([]string, 0, len(u.forms))
+    for s := range u.forms {
+        stems = append(stems, s)
+    }
+    sort.Strings(stems)
+
+    bw := bufio.NewWriter(w)
+    total := int64(0)
+    for _, s := range stems {
+        forms := make([]string, 0, len(u.forms[s]))
+        for f := range u.forms[s] {
+            forms = append(forms, f)
+        }
+        sort.Strings(forms)
+
+        for _, f := range forms {
+            n, err := fmt.Fprintf(bw, "%s\t%s\t%d\n", s, f, u.forms[s][f])
+            total += int64(n)
+            if err != nil {
+                return total, err
+            }
+        }
+    }
+    return total, bw.Flush()
+}
+
+// ReadFrom implements the io.ReaderFrom interface. Counts written by
+// WriteTo are added to the current ones, so a saved unstemmer can be
+// loaded and trained further.
+func (u *Unstemmer) ReadFrom(r io.Reader) (int64, error) {
+    cr := &counting.Reader{R: r}
+    sc := bufio.NewScanner(cr)
+    for line := 1; sc.Scan(); line++ {
+        fields := strings.Split(sc.Text(), "\t")
+        if len(fields) != 3 {
+            return cr.N, fmt.Errorf("ptstemmer: line %d: invalid unstemmer entry", line)
+        }
+        c, err := strconv.Atoi(fields[2])
+        if err != nil {
+            return cr.N, fmt.Errorf("ptstemmer: line %d: invalid count %q", line, fields[2])
+        }
+        u.addForm(fields[0], fields[1], c)
+    }
+    return cr.N, sc.Err()
+}
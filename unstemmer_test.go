@@ -0,0 +1,94 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package ptstemmer
+
+import (
+    "bytes"
+    "strings"
+    "testing"
+)
+
+// TestUnstem checks if stems are mapped to their most frequent or
+// shortest form.
+func TestUnstem(t *testing.T) {
+    u := NewUnstemmer(NewPorterStemmer())
+    err := u.Train(strings.NewReader("Ajudaram a ajudar quem ajudou.\n" +
+        "Ajudar é bom; o governo ajudou.\nAjuda"))
+    if err != nil {
+        t.Fatalf("Could not train: %v\n", err)
+    }
+
+    if f, ok := u.Unstem("ajud"); !ok || f != "ajudar" {
+        t.Errorf("Invalid form. stem= ajud expected= ajudar actual= %s\n", f)
+    }
+
+    u.Strategy = Shortest
+    if f, _ := u.Unstem("ajud"); f != "ajuda" {
+        t.Errorf("Invalid form. stem= ajud expected= ajuda actual= %s\n", f)
+    }
+
+    if f, ok := u.Unstem("xyz"); ok || f != "xyz" {
+        t.Errorf("Unknown stem was found. stem= xyz actual= %s\n", f)
+    }
+
+    if c := u.Forms("govern")["governo"]; c != 1 {
+        t.Errorf("Wrong count. form= governo expected= 1 actual= %d\n", c)
+    }
+}
+
+// TestUnstemmerPersistence checks if counts survive a round trip and
+// can be updated after loading.
+func TestUnstemmerPersistence(t *testing.T) {
+    ps := NewPorterStemmer()
+    u := NewUnstemmer(ps)
+    u.AddCount("governo", 3)
+    u.AddCount("governar", 2)
+
+    var buf bytes.Buffer
+    n, err := u.WriteTo(&buf)
+    if err != nil || n != int64(buf.Len()) {
+        t.Fatalf("Could not write unstemmer. n= %d err= %v\n", n, err)
+    }
+
+    loaded := NewUnstemmer(ps)
+    size := int64(buf.Len())
+    if n, err := loaded.ReadFrom(&buf); err != nil || n != size {
+        t.Fatalf("Could not read unstemmer. n= %d err= %v\n", n, err)
+    }
+    if f, _ := loaded.Unstem("govern"); f != "governo" {
+        t.Errorf("Invalid form. stem= govern expected= governo actual= %s\n", f)
+    }
+
+    loaded.AddCount("governar", 2)
+    if f, _ := loaded.Unstem("govern"); f != "governar" {
+        t.Errorf("Invalid form. stem= govern expected= governar actual= %s\n", f)
+    }
+
+    if _, err := loaded.ReadFrom(strings.NewReader("govern\tgoverno\n")); err == nil {
+        t.Errorf("Invalid data accepted\n")
+    }
+}
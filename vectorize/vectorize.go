@@ -0,0 +1,335 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package vectorize turns portuguese text into sparse feature vectors of
+// stems and stem n-grams, ready to be consumed by classifiers. Features
+// are either learnt from a collection of documents, pruned by document
+// frequency, or hashed into a fixed number of buckets. Any
+// ptstemmer.Stemmer can be used.
+package vectorize
+
+import (
+    "encoding/gob"
+    "hash/fnv"
+    "io"
+    "math"
+    "sort"
+    "strings"
+
+    "github.com/tncardoso/ptstemmer"
+    "github.com/tncardoso/ptstemmer/internal/counting"
+)
+
+// Weighting selects the value of each feature of a vector.
+type Weighting int
+
+const (
+    // Count weights features by their number of occurrences.
+    Count Weighting = iota
+    // Binary weights features by 1 if present.
+    Binary
+    // TFIDF weights features by their occurrences times their smoothed
+    // inverse document frequency.
+    TFIDF
+)
+
+// Vector is a sparse vector with indices in increasing order.
+type Vector struct {
+    Indices []int     // Feature indices
+    Values  []float64 // Value of each feature
+}
+
+// Dot returns the dot product of two vectors.
+func (v Vector) Dot(o Vector) float64 {
+    res := 0.0
+    for i, j := 0, 0; i < len(v.Indices) && j < len(o.Indices); {
+        switch {
+        case v.Indices[i] < o.Indices[j]:
+            i++
+        case v.Indices[i] > o.Indices[j]:
+            j++
+        default:
+            res += v.Values[i] * o.Values[j]
+            i++
+            j++
+        }
+    }
+    return res
+}
+
+// Norm returns the euclidean norm of the vector.
+func (v Vector) Norm() float64 {
+    return math.Sqrt(v.Dot(v))
+}
+
+// Vectorizer converts text to vectors. The exported fields configure the
+// features and should be set before Fit.
+type Vectorizer struct {
+    analyzer    *ptstemmer.Analyzer // Analyzer producing terms
+    vocabulary  map[string]int      // Index of each learnt feature
+    features    []string            // Feature of each index
+    docFreq     []int               // Document frequency of each index
+    docs        int                 // Documents seen by Fit
+    Weighting   Weighting           // Value of features
+    MinN        int                 // Smallest n-gram
+    MaxN        int                 // Largest n-gram
+    MinDF       int                 // Minimum documents with a feature
+    MaxDF       float64             // Maximum fraction of documents with a feature
+    MaxFeatures int                 // Keep only the most frequent features, if positive
+    HashSize    int                 // Hash features into buckets, if positive
+    Normalize   bool                // Scale vectors to unit norm
+}
+
+// Create a vectorizer of stem unigrams weighted by TF-IDF, using the
+// standard analyzer with the stemmer. A nil stemmer vectorizes words.
+func NewVectorizer(stemmer ptstemmer.Stemmer) *Vectorizer {
+    v := new(Vectorizer)
+    v.analyzer = ptstemmer.NewAnalyzer(stemmer)
+    v.vocabulary = make(map[string]int)
+    v.Weighting = TFIDF
+    v.MinN = 1
+    v.MaxN = 1
+    v.MinDF = 1
+    v.MaxDF = 1
+    v.Normalize = true
+    return v
+}
+
+// Terms returns the stem n-grams of a text, in order of occurrence.
+// Stems of an n-gram are separated by spaces.
+func (v *Vectorizer) Terms(text string) []string {
+    stems := v.analyzer.Terms(text)
+    res := make([]string, 0, len(stems))
+    for n := v.MinN; n <= v.MaxN; n++ {
+        for i := 0; i+n <= len(stems); i++ {
+            res = append(res, strings.Join(stems[i:i+n], " "))
+        }
+    }
+    return res
+}
+
+// Return the hash bucket of a term.
+func (v *Vectorizer) bucket(term string) int {
+    h := fnv.New32a()
+    h.Write([]byte(term))
+    return int(h.Sum32() % uint32(v.HashSize))
+}
+
+// Return the feature index of a term, or -1 if it is not a feature.
+func (v *Vectorizer) index(term string) int {
+    if v.HashSize > 0 {
+        return v.bucket(term)
+    }
+    if i, ok := v.vocabulary[term]; ok {
+        return i
+    }
+    return -1
+}
+
+// Fit learns the features and their document frequencies from a
+// collection, replacing previously learnt ones. When hashing, features
+// are not pruned.
+func (v *Vectorizer) Fit(docs []string) {
+    df := make(map[string]int)
+    for _, doc := range docs {
+        seen := make(map[string]bool)
+        for _, term := range v.Terms(doc) {
+            if !seen[term] {
+                seen[term] = true
+                df[term]++
+            }
+        }
+    }
+    v.docs = len(docs)
+
+    if v.HashSize > 0 {
+        v.vocabulary = make(map[string]int)
+        v.features = nil
+        v.docFreq = make([]int, v.HashSize)
+        for _, doc := range docs {
+            seen := make(map[int]bool)
+            for _, term := range v.Terms(doc) {
+                seen[v.bucket(term)] = true
+            }
+            for b := range seen {
+                v.docFreq[b]++
+            }
+        }
+        return
+    }
+
+    maxDF := int(math.Floor(v.MaxDF * float64(len(docs))))
+    terms := make([]string, 0, len(df))
+    for term, n := range df {
+        if n >= v.MinDF && n <= maxDF {
+            terms = append(terms, term)
+        }
+    }
+
+    if v.MaxFeatures > 0 && len(terms) > v.MaxFeatures {
+        sort.Slice(terms, func(i, j int) bool {
+            if df[terms[i]] != df[terms[j]] {
+                return df[terms[i]] > df[terms[j]]
+            }
+            return terms[i] < terms[j]
+        })
+        terms = terms[:v.MaxFeatures]
+    }
+    sort.Strings(terms)
+
+    v.vocabulary = make(map[string]int, len(terms))
+    v.features = terms
+    v.docFreq = make([]int, len(terms))
+    for i, term := range terms {
+        v.vocabulary[term] = i
+        v.docFreq[i] = df[term]
+    }
+}
+
+// Return the smoothed inverse document frequency of a feature.
+func (v *Vectorizer) idf(i int) float64 {
+    df := 0
+    if i < len(v.docFreq) {
+        df = v.docFreq[i]
+    }
+    return math.Log(float64(v.docs+1)/float64(df+1)) + 1
+}
+
+// Transform converts a text to a vector. Terms that are not features are
+// ignored.
+func (v *Vectorizer) Transform(text string) Vector {
+    counts := make(map[int]float64)
+    for _, term := range v.Terms(text) {
+        if i := v.index(term); i >= 0 {
+            counts[i]++
+        }
+    }
+
+    res := Vector{
+        Indices: make([]int, 0, len(counts)),
+        Values:  make([]float64, 0, len(counts)),
+    }
+    for i := range counts {
+        res.Indices = append(res.Indices, i)
+    }
+    sort.Ints(res.Indices)
+
+    for _, i := range res.Indices {
+        value := counts[i]
+        switch v.Weighting {
+        case Binary:
+            value = 1
+        case TFIDF:
+            value *= v.idf(i)
+        }
+        res.Values = append(res.Values, value)
+    }
+
+    if norm := res.Norm(); v.Normalize && norm > 0 {
+        for i := range res.Values {
+            res.Values[i] /= norm
+        }
+    }
+    return res
+}
+
+// FitTransform fits the collection and returns the vector of each
+// document.
+func (v *Vectorizer) FitTransform(docs []string) []Vector {
+    v.Fit(docs)
+    res := make([]Vector, len(docs))
+    for i, doc := range docs {
+        res[i] = v.Transform(doc)
+    }
+    return res
+}
+
+// Features returns the feature of each index. It is empty when hashing.
+func (v *Vectorizer) Features() []string {
+    return v.features
+}
+
+// Len returns the vector dimension.
+func (v *Vectorizer) Len() int {
+    if v.HashSize > 0 {
+        return v.HashSize
+    }
+    return len(v.features)
+}
+
+// snapshot is the persisted state of a vectorizer.
+type snapshot struct {
+    Weighting Weighting
+    MinN      int
+    MaxN      int
+    HashSize  int
+    Normalize bool
+    Features  []string
+    DocFreq   []int
+    Docs      int
+}
+
+// WriteTo implements the io.WriterTo interface, writing the
+// configuration and the learnt features. The analyzer is not included.
+func (v *Vectorizer) WriteTo(w io.Writer) (int64, error) {
+    cw := &counting.Writer{W: w}
+    err := gob.NewEncoder(cw).Encode(snapshot{
+        Weighting: v.Weighting,
+        MinN:      v.MinN,
+        MaxN:      v.MaxN,
+        HashSize:  v.HashSize,
+        Normalize: v.Normalize,
+        Features:  v.features,
+        DocFreq:   v.docFreq,
+        Docs:      v.docs,
+    })
+    return cw.N, err
+}
+
+// ReadFrom implements the io.ReaderFrom interface, replacing the
+// configuration and features by ones written by WriteTo. It should be
+// read by a vectorizer using the stemmer that wrote it.
+func (v *Vectorizer) ReadFrom(r io.Reader) (int64, error) {
+    cr := &counting.Reader{R: r}
+    var s snapshot
+    if err := gob.NewDecoder(cr).Decode(&s); err != nil {
+        return cr.N, err
+    }
+
+    v.Weighting = s.Weighting
+    v.MinN = s.MinN
+    v.MaxN = s.MaxN
+    v.HashSize = s.HashSize
+    v.Normalize = s.Normalize
+    v.features = s.Features
+    v.docFreq = s.DocFreq
+    v.docs = s.Docs
+    v.vocabulary = make(map[string]int, len(s.Features))
+    for i, term := range s.Features {
+        v.vocabulary[term] = i
+    }
+    return cr.N, nil
+}
@@ -0,0 +1,141 @@
+// ptstemmer - Portuguese stemmer for Go
+// 
+// Copyright (c) 2013 - Thiago Cardoso <thiagoncc@gmail.com>
+// 
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without
+// modification, are permitted provided that the following conditions are met: 
+// 
+// 1. Redistributions of source code must retain the above copyright notice, this
+//    list of conditions and the following disclaimer. 
+// 2. Redistributions in binary form must reproduce the above copyright notice,
+//    this list of conditions and the following disclaimer in the documentation
+//    and/or other materials provided with the distribution. 
+// 
+// THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+// ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+// WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+// DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT OWNER OR CONTRIBUTORS BE LIABLE FOR
+// ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES
+// (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES;
+// LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+// SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package vectorize
+
+import (
+    "bytes"
+    "math"
+    "reflect"
+    "testing"
+
+    "github.com/tncardoso/ptstemmer"
+)
+
+// Collection used by the tests.
+var docs = []string{
+    "Os gatos dormem no sofá.",
+    "O gato dormiu na cama.",
+    "Os cachorros correm no parque.",
+}
+
+// TestFit checks the learnt features and document frequency pruning.
+func TestFit(t *testing.T) {
+    tests := []struct {
+        minN, maxN, minDF int
+        maxDF             float64
+        maxFeatures       int
+        expected          []string
+    }{
+        {1, 1, 1, 1, 0, []string{"cachorr", "cam", "corr", "dorm", "gat", "parqu", "sof"}},
+        {1, 1, 2, 1, 0, []string{"dorm", "gat"}},
+        {1, 1, 1, 0.5, 0, []string{"cachorr", "cam", "corr", "parqu", "sof"}},
+        {1, 1, 1, 1, 3, []string{"cachorr", "dorm", "gat"}},
+        {2, 2, 2, 1, 0, []string{"gat dorm"}},
+    }
+
+    for _, test := range tests {
+        v := NewVectorizer(ptstemmer.NewPorterStemmer())
+        v.MinN, v.MaxN = test.minN, test.maxN
+        v.MinDF, v.MaxDF = test.minDF, test.maxDF
+        v.MaxFeatures = test.maxFeatures
+        v.Fit(docs)
+        if !reflect.DeepEqual(v.Features(), test.expected) {
+            t.Errorf("Wrong features. expected= %v actual= %v\n", test.expected, v.Features())
+        }
+    }
+}
+
+// TestTransform checks the vector weightings.
+func TestTransform(t *testing.T) {
+    v := NewVectorizer(ptstemmer.NewPorterStemmer())
+    v.Weighting = Count
+    v.Normalize = false
+    v.Fit(docs)
+
+    vec := v.Transform("Gatinhos? Os gatos dormem, o gato dorme. Peixes nadam.")
+    expected := Vector{Indices: []int{3, 4}, Values: []float64{2, 2}}
+    if !reflect.DeepEqual(vec, expected) {
+        t.Errorf("Wrong counts. expected= %v actual= %v\n", expected, vec)
+    }
+
+    v.Weighting = Binary
+    if vec := v.Transform("gato gatos"); !reflect.DeepEqual(vec.Values, []float64{1}) {
+        t.Errorf("Wrong binary values: %v\n", vec)
+    }
+
+    v.Weighting = TFIDF
+    v.Normalize = true
+    vec = v.Transform("gatos no parque")
+    if vec.Values[1] <= vec.Values[0] {
+        t.Errorf("Rare features should weigh more: %v\n", vec)
+    }
+    if n := vec.Norm(); math.Abs(n-1) > 1e-9 {
+        t.Errorf("Vector should have unit norm: %f\n", n)
+    }
+}
+
+// TestHashing checks if hashed features need no vocabulary.
+func TestHashing(t *testing.T) {
+    v := NewVectorizer(ptstemmer.NewPorterStemmer())
+    v.HashSize = 16
+    v.Weighting = Count
+    v.Normalize = false
+
+    a := v.Transform("gatos")
+    b := v.Transform("gato")
+    if len(a.Indices) != 1 || !reflect.DeepEqual(a, b) || a.Indices[0] >= 16 {
+        t.Errorf("Wrong hashed vectors: %v %v\n", a, b)
+    }
+    if v.Len() != 16 {
+        t.Errorf("Wrong dimension: %d\n", v.Len())
+    }
+}
+
+// TestPersistence checks if a read vectorizer transforms like the
+// written one.
+func TestPersistence(t *testing.T) {
+    v := NewVectorizer(ptstemmer.NewPorterStemmer())
+    v.MaxN = 2
+    v.Fit(docs)
+
+    var buf bytes.Buffer
+    if _, err := v.WriteTo(&buf); err != nil {
+        t.Fatal(err)
+    }
+
+    r := NewVectorizer(ptstemmer.NewPorterStemmer())
+    if _, err := r.ReadFrom(&buf); err != nil {
+        t.Fatal(err)
+    }
+    if !reflect.DeepEqual(r.Features(), v.Features()) {
+        t.Errorf("Wrong features: %v\n", r.Features())
+    }
+    text := "o gato dorme no parque"
+    if !reflect.DeepEqual(r.Transform(text), v.Transform(text)) {
+        t.Errorf("Wrong vector: %v\n", r.Transform(text))
+    }
+}